@@ -3,13 +3,17 @@ package auth
 import (
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// ErrNotAuthenticated는 API 키 없이 인증이 필요한 API를 호출했을 때 반환됩니다.
+var ErrNotAuthenticated = errors.New("not authenticated: API keys are required")
+
 // RestTokenGenerator는 REST API용 토큰 생성 기능을 정의하는 인터페이스입니다.
 type RestTokenGenerator interface {
 	GenerateToken() (string, error)
@@ -29,46 +33,108 @@ type Credentials struct {
 }
 
 type WebSocketTokenGen struct {
-	creds Credentials
+	signer Signer
+	opts   tokenOptions
 }
 
 type RestTokenGen struct {
-	creds Credentials
+	signer Signer
+	opts   tokenOptions
+}
+
+// tokenOptions는 토큰 생성기의 nonce와 시각 설정입니다.
+type tokenOptions struct {
+	nonce func() string    // nonce 생성 함수
+	now   func() time.Time // 현재 시각 함수 (nil이면 iat 클레임을 넣지 않음)
+}
+
+// TokenGenOption은 토큰 생성기의 설정을 변경하는 함수 타입입니다.
+type TokenGenOption func(*tokenOptions)
+
+// WithNonceFunc는 nonce 생성 함수를 설정하는 옵션을 반환합니다.
+// 기본값은 UUID v4입니다. 테스트에서 토큰을 고정된 값과 비교할 때 사용하며,
+// 실제 서버에 요청할 때는 매번 다른 값을 반환해야 합니다.
+func WithNonceFunc(fn func() string) TokenGenOption {
+	return func(o *tokenOptions) {
+		o.nonce = fn
+	}
+}
+
+// WithClock은 토큰 발급 시각에 사용할 시계를 설정하는 옵션을 반환합니다.
+// 시계를 설정하면 토큰에 iat(발급 시각, Unix 초) 클레임이 추가됩니다.
+func WithClock(now func() time.Time) TokenGenOption {
+	return func(o *tokenOptions) {
+		o.now = now
+	}
+}
+
+// newTokenOptions는 기본값에 opts를 적용한 설정을 반환합니다.
+func newTokenOptions(opts []TokenGenOption) tokenOptions {
+	o := tokenOptions{nonce: func() string { return uuid.New().String() }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func NewRestTokenGen(creds Credentials, opts ...TokenGenOption) *RestTokenGen {
+	return NewRestTokenGenWithProvider(NewStaticProvider(creds), opts...)
+}
+
+func NewWebSocketTokenGen(creds Credentials, opts ...TokenGenOption) *WebSocketTokenGen {
+	return NewWebSocketTokenGenWithProvider(NewStaticProvider(creds), opts...)
 }
 
-func NewRestTokenGen(creds Credentials) *RestTokenGen {
-	return &RestTokenGen{creds: creds}
+// NewRestTokenGenWithProvider는 토큰을 생성할 때마다 provider에서 인증 정보를 가져오는
+// REST API용 토큰 생성기를 생성합니다.
+func NewRestTokenGenWithProvider(provider CredentialsProvider, opts ...TokenGenOption) *RestTokenGen {
+	return NewRestTokenGenWithSigner(NewHMACSigner(provider), opts...)
 }
 
-func NewWebSocketTokenGen(creds Credentials) *WebSocketTokenGen {
-	return &WebSocketTokenGen{creds: creds}
+// NewWebSocketTokenGenWithProvider는 토큰을 생성할 때마다 provider에서 인증 정보를 가져오는
+// 웹소켓용 토큰 생성기를 생성합니다.
+func NewWebSocketTokenGenWithProvider(provider CredentialsProvider, opts ...TokenGenOption) *WebSocketTokenGen {
+	return NewWebSocketTokenGenWithSigner(NewHMACSigner(provider), opts...)
+}
+
+// NewRestTokenGenWithSigner는 signer에 서명을 맡기는 REST API용 토큰 생성기를 생성합니다.
+// 시크릿 키를 프로세스에 두지 않을 때 사용합니다.
+func NewRestTokenGenWithSigner(signer Signer, opts ...TokenGenOption) *RestTokenGen {
+	return &RestTokenGen{signer: signer, opts: newTokenOptions(opts)}
+}
+
+// NewWebSocketTokenGenWithSigner는 signer에 서명을 맡기는 웹소켓용 토큰 생성기를 생성합니다.
+func NewWebSocketTokenGenWithSigner(signer Signer, opts ...TokenGenOption) *WebSocketTokenGen {
+	return &WebSocketTokenGen{signer: signer, opts: newTokenOptions(opts)}
 }
 
 // generateToken은 기본 JWT 토큰 생성 로직을 담당하는 헬퍼 함수입니다.
-func generateToken(creds Credentials, payload map[string]interface{}) (string, error) {
+// nonce(시계가 설정되어 있으면 iat도)를 추가한 클레임을 signer로 서명합니다.
+func generateToken(signer Signer, opts tokenOptions, payload map[string]interface{}) (string, error) {
 	if payload == nil {
 		payload = map[string]interface{}{}
 	}
 
-	payload["access_key"] = creds.AccessKey
-	payload["nonce"] = uuid.New().String()
+	payload["nonce"] = opts.nonce()
+	if opts.now != nil {
+		payload["iat"] = opts.now().Unix()
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(payload))
-	return token.SignedString([]byte(creds.SecretKey))
+	return signer.Sign(payload)
 }
 
 func (g *RestTokenGen) GenerateToken() (string, error) {
-	tokenString, err := generateToken(g.creds, nil)
+	tokenString, err := generateToken(g.signer, g.opts, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate REST token: %v", err)
+		return "", fmt.Errorf("failed to generate REST token: %w", err)
 	}
 	return tokenString, nil
 }
 
 func (g *WebSocketTokenGen) GenerateToken() (string, error) {
-	tokenString, err := generateToken(g.creds, nil)
+	tokenString, err := generateToken(g.signer, g.opts, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate WebSocket token: %v", err)
+		return "", fmt.Errorf("failed to generate WebSocket token: %w", err)
 	}
 	return "Bearer " + tokenString, nil
 }
@@ -88,9 +154,9 @@ func (g *RestTokenGen) GenerateTokenWithQuery(query url.Values) (string, error)
 		payload["query_hash_alg"] = "SHA512"
 	}
 
-	tokenString, err := generateToken(g.creds, payload)
+	tokenString, err := generateToken(g.signer, g.opts, payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token with query: %v", err)
+		return "", fmt.Errorf("failed to generate token with query: %w", err)
 	}
 
 	return "Bearer " + tokenString, nil
@@ -105,9 +171,9 @@ func (g *RestTokenGen) GenerateTokenWithBody(body string) (string, error) {
 		payload["query_hash_alg"] = "SHA512"
 	}
 
-	tokenString, err := generateToken(g.creds, payload)
+	tokenString, err := generateToken(g.signer, g.opts, payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token with body: %v", err)
+		return "", fmt.Errorf("failed to generate token with body: %w", err)
 	}
 
 	return "Bearer " + tokenString, nil
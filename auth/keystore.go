@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultKeystoreEntry는 이름을 지정하지 않을 때 사용하는 키 저장소 항목 이름입니다.
+const DefaultKeystoreEntry = "default"
+
+// 키 저장소 관련 에러입니다.
+var (
+	ErrWrongPassphrase       = errors.New("wrong keystore passphrase")
+	ErrKeystoreExists        = errors.New("keystore already exists")
+	ErrKeystoreEntryExists   = errors.New("keystore entry already exists")
+	ErrKeystoreEntryNotFound = errors.New("keystore entry not found")
+)
+
+// 키 저장소 파일 형식과 암호화 설정입니다.
+const (
+	keystoreVersion    = 1
+	keystoreKDF        = "pbkdf2-sha256"
+	keystoreCipher     = "aes-256-gcm"
+	keystoreIterations = 600000   // PBKDF2 반복 횟수 (파일에서 읽을 때 허용하는 최솟값)
+	keystoreMaxIter    = 10000000 // 파일에서 읽을 때 허용하는 PBKDF2 반복 횟수 최댓값
+	keystoreSaltSize   = 16
+	keystoreKeySize    = 32
+	keystoreCheckValue = "go-upbit keystore" // 비밀번호 확인용 평문
+)
+
+// keystoreFile은 키 저장소 파일의 JSON 형식입니다.
+type keystoreFile struct {
+	Version    int                       `json:"version"`
+	KDF        string                    `json:"kdf"`
+	Iterations int                       `json:"iterations"`
+	Salt       []byte                    `json:"salt"`
+	Cipher     string                    `json:"cipher"`
+	Check      sealedBox                 `json:"check"`
+	Entries    map[string]*keystoreEntry `json:"entries"`
+}
+
+// keystoreEntry는 암호화된 인증 정보 하나입니다.
+type keystoreEntry struct {
+	sealedBox
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// sealedBox는 AES-GCM으로 암호화한 데이터입니다.
+type sealedBox struct {
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keystore는 비밀번호로 암호화된 파일에 인증 정보를 저장하는 키 저장소입니다.
+// 비밀번호에서 PBKDF2-HMAC-SHA256으로 유도한 키로 항목마다 AES-256-GCM 암호화를 하며,
+// 항목 이름을 추가 인증 데이터로 사용하므로 항목끼리 바꿔치기할 수 없습니다.
+//
+//	ks, err := auth.CreateKeystore("upbit.keystore", passphrase)
+//	err = ks.Add(auth.DefaultKeystoreEntry, auth.Credentials{AccessKey: ak, SecretKey: sk})
+//
+//	ks, err = auth.OpenKeystore("upbit.keystore", passphrase)
+//	creds, err := ks.Credentials(auth.DefaultKeystoreEntry)
+type Keystore struct {
+	path string
+	aead cipher.AEAD
+
+	mu   sync.Mutex
+	file keystoreFile
+}
+
+// CreateKeystore는 path에 passphrase로 암호화되는 빈 키 저장소를 생성합니다.
+// 파일이 이미 있으면 ErrKeystoreExists를 반환합니다.
+func CreateKeystore(path, passphrase string) (*Keystore, error) {
+	if passphrase == "" {
+		return nil, errors.New("keystore passphrase is required")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeystoreExists, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check keystore: %w", err)
+	}
+
+	salt := make([]byte, keystoreSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	ks := &Keystore{
+		path: path,
+		file: keystoreFile{
+			Version:    keystoreVersion,
+			KDF:        keystoreKDF,
+			Iterations: keystoreIterations,
+			Salt:       salt,
+			Cipher:     keystoreCipher,
+			Entries:    map[string]*keystoreEntry{},
+		},
+	}
+	if err := ks.unlock(passphrase); err != nil {
+		return nil, err
+	}
+
+	check, err := ks.seal([]byte(keystoreCheckValue), "")
+	if err != nil {
+		return nil, err
+	}
+	ks.file.Check = check
+
+	if err := ks.save(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// OpenKeystore는 path의 키 저장소를 passphrase로 엽니다.
+// 비밀번호가 틀리면 ErrWrongPassphrase를 반환합니다.
+func OpenKeystore(path, passphrase string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	ks := &Keystore{path: path}
+	if err := json.Unmarshal(data, &ks.file); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore: %w", err)
+	}
+	if ks.file.Version != keystoreVersion || ks.file.KDF != keystoreKDF || ks.file.Cipher != keystoreCipher {
+		return nil, fmt.Errorf("unsupported keystore format: version=%d kdf=%s cipher=%s", ks.file.Version, ks.file.KDF, ks.file.Cipher)
+	}
+	if ks.file.Entries == nil {
+		ks.file.Entries = map[string]*keystoreEntry{}
+	}
+
+	if err := ks.unlock(passphrase); err != nil {
+		return nil, err
+	}
+	if _, err := ks.open(ks.file.Check, ""); err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return ks, nil
+}
+
+// Add는 name 항목에 creds를 암호화하여 저장합니다.
+// 같은 이름의 항목이 있으면 ErrKeystoreEntryExists를 반환합니다.
+func (ks *Keystore) Add(name string, creds Credentials) error {
+	if name == "" {
+		return errors.New("keystore entry name is required")
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return errors.New("access key and secret key are required")
+	}
+
+	plaintext, err := json.Marshal(credentialsFile{AccessKey: creds.AccessKey, SecretKey: creds.SecretKey})
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if _, ok := ks.file.Entries[name]; ok {
+		return fmt.Errorf("%w: %s", ErrKeystoreEntryExists, name)
+	}
+
+	box, err := ks.seal(plaintext, name)
+	if err != nil {
+		return err
+	}
+	ks.file.Entries[name] = &keystoreEntry{sealedBox: box, CreatedAt: time.Now().UTC()}
+	if err := ks.save(); err != nil {
+		delete(ks.file.Entries, name)
+		return err
+	}
+	return nil
+}
+
+// Credentials는 name 항목의 인증 정보를 복호화하여 반환합니다.
+func (ks *Keystore) Credentials(name string) (Credentials, error) {
+	ks.mu.Lock()
+	entry, ok := ks.file.Entries[name]
+	ks.mu.Unlock()
+	if !ok {
+		return Credentials{}, fmt.Errorf("%w: %s", ErrKeystoreEntryNotFound, name)
+	}
+
+	plaintext, err := ks.open(entry.sealedBox, name)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to decrypt keystore entry %s: %w", name, err)
+	}
+	var file credentialsFile
+	if err := json.Unmarshal(plaintext, &file); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse keystore entry %s: %w", name, err)
+	}
+	return Credentials{AccessKey: file.AccessKey, SecretKey: file.SecretKey}, nil
+}
+
+// Provider는 name 항목의 인증 정보를 제공하는 CredentialsProvider를 반환합니다.
+func (ks *Keystore) Provider(name string) (CredentialsProvider, error) {
+	creds, err := ks.Credentials(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaticProvider(creds), nil
+}
+
+// List는 저장된 항목 이름을 정렬하여 반환합니다.
+func (ks *Keystore) List() []string {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	names := make([]string, 0, len(ks.file.Entries))
+	for name := range ks.file.Entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Delete는 name 항목을 삭제합니다.
+// 항목이 없으면 ErrKeystoreEntryNotFound를 반환합니다.
+func (ks *Keystore) Delete(name string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	entry, ok := ks.file.Entries[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKeystoreEntryNotFound, name)
+	}
+	delete(ks.file.Entries, name)
+	if err := ks.save(); err != nil {
+		ks.file.Entries[name] = entry
+		return err
+	}
+	return nil
+}
+
+// unlock은 passphrase로 암호화 키를 유도합니다.
+func (ks *Keystore) unlock(passphrase string) error {
+	// 변조된 파일이 반복 횟수를 낮춰 무차별 대입을 쉽게 하거나, 지나치게 높여 멈추게 하지 못하도록 범위를 제한합니다.
+	if ks.file.Iterations < keystoreIterations || ks.file.Iterations > keystoreMaxIter {
+		return fmt.Errorf("invalid keystore iterations %d: must be between %d and %d", ks.file.Iterations, keystoreIterations, keystoreMaxIter)
+	}
+	if len(ks.file.Salt) == 0 {
+		return errors.New("invalid keystore KDF parameters")
+	}
+
+	key := pbkdf2SHA256([]byte(passphrase), ks.file.Salt, ks.file.Iterations, keystoreKeySize)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	ks.aead = aead
+	return nil
+}
+
+// seal은 plaintext를 name을 추가 인증 데이터로 하여 암호화합니다.
+func (ks *Keystore) seal(plaintext []byte, name string) (sealedBox, error) {
+	nonce := make([]byte, ks.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return sealedBox{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return sealedBox{
+		Nonce:      nonce,
+		Ciphertext: ks.aead.Seal(nil, nonce, plaintext, []byte(name)),
+	}, nil
+}
+
+// open은 seal로 암호화한 데이터를 복호화합니다.
+func (ks *Keystore) open(box sealedBox, name string) ([]byte, error) {
+	if len(box.Nonce) != ks.aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	return ks.aead.Open(nil, box.Nonce, box.Ciphertext, []byte(name))
+}
+
+// save는 키 저장소를 임시 파일에 쓴 뒤 교체하여 저장합니다.
+// 파일은 소유자만 읽을 수 있도록 0600 권한으로 생성됩니다.
+func (ks *Keystore) save() error {
+	data, err := json.MarshalIndent(ks.file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(ks.path), filepath.Base(ks.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), ks.path); err != nil {
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+	return nil
+}
+
+// pbkdf2SHA256은 RFC 8018의 PBKDF2를 HMAC-SHA256으로 계산합니다.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var counter [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		dk = prf.Sum(dk)
+
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+		for i := 2; i <= iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range u {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
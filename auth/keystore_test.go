@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		iterations     int
+		keyLen         int
+		want           string
+	}{
+		{
+			password: "passwd", salt: "salt", iterations: 1, keyLen: 64,
+			want: "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+		},
+		{
+			password: "password", salt: "salt", iterations: 2, keyLen: 32,
+			want: "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43",
+		},
+		{
+			password: "passwordPASSWORDpassword", salt: "saltSALTsaltSALTsaltSALTsaltSALTsalt", iterations: 4096, keyLen: 40,
+			want: "348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9",
+		},
+	}
+
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLen))
+		if got != tt.want {
+			t.Errorf("pbkdf2SHA256(%q, %q, %d, %d) = %s, want %s", tt.password, tt.salt, tt.iterations, tt.keyLen, got, tt.want)
+		}
+	}
+}
+
+// newTestKeystore는 임시 디렉터리에 항목 하나가 저장된 키 저장소를 생성하고 경로를 반환합니다.
+func newTestKeystore(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "upbit.keystore")
+	ks, err := CreateKeystore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("CreateKeystore error: %v", err)
+	}
+	if err := ks.Add(DefaultKeystoreEntry, Credentials{AccessKey: "ak", SecretKey: "sk"}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	if err := ks.Add("sub", Credentials{AccessKey: "sub-ak", SecretKey: "sub-sk"}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	return path
+}
+
+// rewriteKeystore는 path의 키 저장소 파일을 읽어 modify로 수정한 뒤 다시 저장합니다.
+func rewriteKeystore(t *testing.T, path string, modify func(*keystoreFile)) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file keystoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	modify(&file)
+	if data, err = json.Marshal(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	path := newTestKeystore(t)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keystore permission = %o, want 600", perm)
+	}
+
+	ks, err := OpenKeystore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenKeystore error: %v", err)
+	}
+	if got := ks.List(); !reflect.DeepEqual(got, []string{DefaultKeystoreEntry, "sub"}) {
+		t.Errorf("List = %v", got)
+	}
+
+	creds, err := ks.Credentials("sub")
+	if err != nil {
+		t.Fatalf("Credentials error: %v", err)
+	}
+	if creds != (Credentials{AccessKey: "sub-ak", SecretKey: "sub-sk"}) {
+		t.Errorf("Credentials = %+v", creds)
+	}
+
+	provider, err := ks.Provider(DefaultKeystoreEntry)
+	if err != nil {
+		t.Fatalf("Provider error: %v", err)
+	}
+	if creds, _ := provider.Credentials(); creds.AccessKey != "ak" {
+		t.Errorf("Provider credentials = %+v", creds)
+	}
+
+	if err := ks.Delete("sub"); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if _, err := ks.Credentials("sub"); !errors.Is(err, ErrKeystoreEntryNotFound) {
+		t.Errorf("Credentials after Delete = %v, want ErrKeystoreEntryNotFound", err)
+	}
+}
+
+func TestKeystoreErrors(t *testing.T) {
+	path := newTestKeystore(t)
+
+	ks, err := OpenKeystore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenKeystore error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "이미 있는 파일 생성", err: func() error { _, err := CreateKeystore(path, "pw"); return err }(), want: ErrKeystoreExists},
+		{name: "틀린 비밀번호", err: func() error { _, err := OpenKeystore(path, "wrong"); return err }(), want: ErrWrongPassphrase},
+		{name: "중복 항목 추가", err: ks.Add("sub", Credentials{AccessKey: "a", SecretKey: "b"}), want: ErrKeystoreEntryExists},
+		{name: "없는 항목 조회", err: func() error { _, err := ks.Credentials("missing"); return err }(), want: ErrKeystoreEntryNotFound},
+		{name: "없는 항목 삭제", err: ks.Delete("missing"), want: ErrKeystoreEntryNotFound},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+
+	if _, err := CreateKeystore(filepath.Join(t.TempDir(), "empty"), ""); err == nil {
+		t.Error("CreateKeystore with empty passphrase succeeded")
+	}
+	if err := ks.Add("", Credentials{AccessKey: "a", SecretKey: "b"}); err == nil {
+		t.Error("Add with empty name succeeded")
+	}
+	if err := ks.Add("partial", Credentials{AccessKey: "a"}); err == nil {
+		t.Error("Add without secret key succeeded")
+	}
+}
+
+func TestOpenKeystoreRejectsTamperedFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*keystoreFile)
+	}{
+		{name: "반복 횟수 0", modify: func(f *keystoreFile) { f.Iterations = 0 }},
+		{name: "반복 횟수 음수", modify: func(f *keystoreFile) { f.Iterations = -1 }},
+		{name: "반복 횟수 최솟값 미만", modify: func(f *keystoreFile) { f.Iterations = keystoreIterations - 1 }},
+		{name: "반복 횟수 1", modify: func(f *keystoreFile) { f.Iterations = 1 }},
+		{name: "반복 횟수 최댓값 초과", modify: func(f *keystoreFile) { f.Iterations = keystoreMaxIter + 1 }},
+		{name: "솔트 없음", modify: func(f *keystoreFile) { f.Salt = nil }},
+		{name: "지원하지 않는 버전", modify: func(f *keystoreFile) { f.Version = 2 }},
+		{name: "지원하지 않는 KDF", modify: func(f *keystoreFile) { f.KDF = "scrypt" }},
+		{name: "지원하지 않는 암호화 방식", modify: func(f *keystoreFile) { f.Cipher = "aes-128-cbc" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := newTestKeystore(t)
+			rewriteKeystore(t, path, tt.modify)
+			if _, err := OpenKeystore(path, "correct horse"); err == nil {
+				t.Fatal("OpenKeystore succeeded with a tampered file")
+			}
+		})
+	}
+}
+
+func TestKeystoreRejectsSwappedEntries(t *testing.T) {
+	path := newTestKeystore(t)
+	rewriteKeystore(t, path, func(f *keystoreFile) {
+		f.Entries[DefaultKeystoreEntry], f.Entries["sub"] = f.Entries["sub"], f.Entries[DefaultKeystoreEntry]
+	})
+
+	ks, err := OpenKeystore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenKeystore error: %v", err)
+	}
+	if creds, err := ks.Credentials(DefaultKeystoreEntry); err == nil {
+		t.Fatalf("Credentials of swapped entry = %+v, want error", creds)
+	}
+}
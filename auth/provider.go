@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// API 키를 읽어오는 기본 환경 변수 이름입니다.
+const (
+	EnvAccessKey = "UPBIT_ACCESS_KEY"
+	EnvSecretKey = "UPBIT_SECRET_KEY"
+)
+
+// CredentialsProvider는 API 인증 정보를 제공하는 인터페이스입니다.
+// 토큰 생성기는 토큰을 만들 때마다 Credentials를 호출하므로, 키가 교체되면
+// 다음 요청부터 새 키가 사용됩니다. 여러 고루틴에서 동시에 호출될 수 있습니다.
+type CredentialsProvider interface {
+	Credentials() (Credentials, error)
+}
+
+// RotationNotifier는 인증 정보가 교체될 때 알림을 보내는 CredentialsProvider가 구현하는 인터페이스입니다.
+// 비공개 웹소켓처럼 연결 시점에만 토큰을 사용하는 클라이언트는 알림을 받아 다시 연결합니다.
+// OnRotate는 등록을 해제하는 함수를 반환합니다.
+type RotationNotifier interface {
+	OnRotate(fn func(Credentials)) (unregister func())
+}
+
+// IsZero는 인증 정보가 비어 있는지 확인합니다.
+func (c Credentials) IsZero() bool {
+	return c == Credentials{}
+}
+
+// StaticProvider는 고정된 인증 정보를 제공합니다.
+type StaticProvider struct {
+	creds Credentials
+}
+
+// NewStaticProvider는 creds를 항상 반환하는 StaticProvider를 생성합니다.
+func NewStaticProvider(creds Credentials) *StaticProvider {
+	return &StaticProvider{creds: creds}
+}
+
+// Credentials는 고정된 인증 정보를 반환합니다.
+func (p *StaticProvider) Credentials() (Credentials, error) {
+	if p.creds.IsZero() {
+		return Credentials{}, ErrNotAuthenticated
+	}
+	return p.creds, nil
+}
+
+// EnvProvider는 환경 변수에서 인증 정보를 읽어옵니다.
+// 호출할 때마다 환경 변수를 다시 읽습니다.
+type EnvProvider struct {
+	accessKeyVar string
+	secretKeyVar string
+}
+
+// NewEnvProvider는 accessKeyVar, secretKeyVar 환경 변수를 읽는 EnvProvider를 생성합니다.
+// 빈 문자열을 전달하면 EnvAccessKey, EnvSecretKey를 사용합니다.
+func NewEnvProvider(accessKeyVar, secretKeyVar string) *EnvProvider {
+	if accessKeyVar == "" {
+		accessKeyVar = EnvAccessKey
+	}
+	if secretKeyVar == "" {
+		secretKeyVar = EnvSecretKey
+	}
+	return &EnvProvider{accessKeyVar: accessKeyVar, secretKeyVar: secretKeyVar}
+}
+
+// Credentials는 환경 변수의 인증 정보를 반환합니다.
+// 환경 변수가 설정되어 있지 않으면 에러를 반환합니다.
+func (p *EnvProvider) Credentials() (Credentials, error) {
+	creds := Credentials{
+		AccessKey: os.Getenv(p.accessKeyVar),
+		SecretKey: os.Getenv(p.secretKeyVar),
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("environment variables %s and %s are required: %w", p.accessKeyVar, p.secretKeyVar, ErrNotAuthenticated)
+	}
+	return creds, nil
+}
+
+// credentialsFile은 FileProvider가 읽는 파일 형식입니다.
+type credentialsFile struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// FileProvider는 JSON 파일({"access_key": "...", "secret_key": "..."})에서 인증 정보를 읽어옵니다.
+// 파일의 수정 시각이 바뀌면 다시 읽으므로, 파일을 교체하면 다음 요청부터 새 키가 사용됩니다.
+type FileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	creds   Credentials
+	modTime time.Time
+	size    int64
+}
+
+// NewFileProvider는 path의 파일을 읽는 FileProvider를 생성합니다.
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+// Credentials는 파일의 인증 정보를 반환합니다.
+// 파일이 없거나 형식이 잘못되었으면 에러를 반환합니다.
+func (p *FileProvider) Credentials() (Credentials, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.creds.IsZero() && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.creds, nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	var file credentialsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	if file.AccessKey == "" || file.SecretKey == "" {
+		return Credentials{}, fmt.Errorf("credentials file %s must contain access_key and secret_key: %w", p.path, ErrNotAuthenticated)
+	}
+
+	p.creds = Credentials{AccessKey: file.AccessKey, SecretKey: file.SecretKey}
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return p.creds, nil
+}
+
+// RotatingProvider는 실행 중에 교체할 수 있는 인증 정보를 제공합니다.
+// Rotate로 키를 교체하면 OnRotate로 등록한 함수가 호출됩니다.
+//
+//	provider := auth.NewRotatingProvider(auth.Credentials{AccessKey: ak, SecretKey: sk})
+//	client, err := upbit.NewUpbitClient(upbit.WithCredentialsProvider(provider))
+//
+//	// 키 교체: REST API는 다음 요청부터, 비공개 웹소켓은 다시 연결되어 새 키를 사용
+//	provider.Rotate(auth.Credentials{AccessKey: newAK, SecretKey: newSK})
+type RotatingProvider struct {
+	mu        sync.RWMutex
+	creds     Credentials
+	listeners []*rotateListener
+}
+
+// rotateListener는 OnRotate로 등록된 함수입니다.
+type rotateListener struct {
+	fn func(Credentials)
+}
+
+// NewRotatingProvider는 initial을 현재 인증 정보로 사용하는 RotatingProvider를 생성합니다.
+func NewRotatingProvider(initial Credentials) *RotatingProvider {
+	return &RotatingProvider{creds: initial}
+}
+
+// Credentials는 현재 인증 정보를 반환합니다.
+func (p *RotatingProvider) Credentials() (Credentials, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.creds.IsZero() {
+		return Credentials{}, ErrNotAuthenticated
+	}
+	return p.creds, nil
+}
+
+// Rotate는 인증 정보를 creds로 교체합니다.
+// 기존과 다른 값이면 OnRotate로 등록한 함수들을 호출한 뒤 반환합니다.
+func (p *RotatingProvider) Rotate(creds Credentials) {
+	p.mu.Lock()
+	if p.creds == creds {
+		p.mu.Unlock()
+		return
+	}
+	p.creds = creds
+	listeners := append([]*rotateListener{}, p.listeners...)
+	p.mu.Unlock()
+
+	for _, l := range listeners {
+		l.fn(creds)
+	}
+}
+
+// OnRotate는 인증 정보가 교체될 때 호출할 함수를 등록하고, 등록을 해제하는 함수를 반환합니다.
+// 해제 함수는 여러 번 호출해도 안전합니다.
+func (p *RotatingProvider) OnRotate(fn func(Credentials)) (unregister func()) {
+	l := &rotateListener{fn: fn}
+
+	p.mu.Lock()
+	p.listeners = append(p.listeners, l)
+	p.mu.Unlock()
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for i, registered := range p.listeners {
+			if registered == l {
+				p.listeners = append(p.listeners[:i:i], p.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Watch는 ctx가 취소될 때까지 interval마다 source의 인증 정보를 확인하고,
+// 바뀌었으면 Rotate를 호출합니다. source가 에러를 반환하면 현재 키를 유지합니다.
+// FileProvider나 EnvProvider의 변경을 비공개 웹소켓에도 적용할 때 사용하며,
+// ctx가 취소되면 ctx.Err()를 반환합니다.
+//
+//	go provider.Watch(ctx, auth.NewFileProvider("/etc/upbit/keys.json"), time.Minute)
+func (p *RotatingProvider) Watch(ctx context.Context, source CredentialsProvider, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if creds, err := source.Credentials(); err == nil {
+			p.Rotate(creds)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
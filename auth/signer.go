@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer는 JWT 클레임에 서명하여 토큰 문자열을 만드는 인터페이스입니다.
+// claims에는 nonce와 query_hash 등 요청별 클레임이 담겨 있으며, Signer는 access_key를
+// 추가한 뒤 서명합니다. 시크릿 키를 프로세스 밖(HSM, 서명 데몬 등)에 둘 때 구현합니다.
+// 여러 고루틴에서 동시에 호출될 수 있습니다.
+type Signer interface {
+	Sign(claims map[string]interface{}) (string, error)
+}
+
+// HMACSigner는 CredentialsProvider의 시크릿 키로 HS256 서명을 하는 기본 Signer입니다.
+type HMACSigner struct {
+	provider CredentialsProvider
+}
+
+// NewHMACSigner는 provider의 인증 정보로 서명하는 HMACSigner를 생성합니다.
+func NewHMACSigner(provider CredentialsProvider) *HMACSigner {
+	return &HMACSigner{provider: provider}
+}
+
+// Sign은 claims에 access_key를 추가하고 시크릿 키로 서명한 토큰을 반환합니다.
+func (s *HMACSigner) Sign(claims map[string]interface{}) (string, error) {
+	creds, err := s.provider.Credentials()
+	if err != nil {
+		return "", err
+	}
+
+	payload := make(jwt.MapClaims, len(claims)+1)
+	for k, v := range claims {
+		payload[k] = v
+	}
+	payload["access_key"] = creds.AccessKey
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	return token.SignedString([]byte(creds.SecretKey))
+}
+
+// UnixSocketSigner는 Unix 소켓으로 연결된 로컬 서명 데몬에 서명을 요청하는 Signer입니다.
+// 시크릿 키는 데몬만 알고 있으며, 이 프로세스에는 토큰만 전달됩니다.
+//
+// 서명할 때마다 소켓에 연결하여 한 줄짜리 JSON 요청을 보내고 한 줄짜리 JSON 응답을 받습니다.
+//
+//	요청: {"claims": {"nonce": "...", "query_hash": "...", "query_hash_alg": "SHA512"}}
+//	응답: {"token": "eyJ..."} 또는 {"error": "..."}
+//
+// 데몬은 access_key 클레임을 추가하고 HS256으로 서명한 토큰을 반환해야 합니다.
+type UnixSocketSigner struct {
+	path    string
+	timeout time.Duration
+}
+
+// signRequest는 서명 데몬에 보내는 요청입니다.
+type signRequest struct {
+	Claims map[string]interface{} `json:"claims"`
+}
+
+// signResponse는 서명 데몬의 응답입니다.
+type signResponse struct {
+	Token string `json:"token"`
+	Error string `json:"error"`
+}
+
+// NewUnixSocketSigner는 path의 Unix 소켓으로 서명을 요청하는 UnixSocketSigner를 생성합니다.
+// timeout은 연결부터 응답 수신까지의 제한 시간이며, 0이면 5초를 사용합니다.
+func NewUnixSocketSigner(path string, timeout time.Duration) *UnixSocketSigner {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &UnixSocketSigner{path: path, timeout: timeout}
+}
+
+// Sign은 서명 데몬에 claims의 서명을 요청하고 토큰을 반환합니다.
+func (s *UnixSocketSigner) Sign(claims map[string]interface{}) (string, error) {
+	conn, err := net.DialTimeout("unix", s.path, s.timeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to signer: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return "", fmt.Errorf("failed to set signer deadline: %w", err)
+	}
+
+	if err := json.NewEncoder(conn).Encode(signRequest{Claims: claims}); err != nil {
+		return "", fmt.Errorf("failed to send sign request: %w", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return "", fmt.Errorf("failed to read sign response: %w", err)
+	}
+
+	var resp signResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse sign response: %w", err)
+	}
+	if resp.Error != "" {
+		return "", fmt.Errorf("signer error: %s", resp.Error)
+	}
+	if resp.Token == "" {
+		return "", errors.New("signer returned empty token")
+	}
+	return resp.Token, nil
+}
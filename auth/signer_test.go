@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startSignDaemon은 연결마다 요청 한 줄을 읽고 handle의 반환값을 한 줄로 응답하는
+// 서명 데몬을 Unix 소켓에서 실행하고 소켓 경로를 반환합니다.
+// handle이 빈 문자열을 반환하면 응답하지 않고 연결을 닫습니다.
+func startSignDaemon(t *testing.T, handle func(req signRequest) string) string {
+	t.Helper()
+
+	// Unix 소켓 경로 길이 제한 때문에 짧은 임시 디렉터리를 사용합니다.
+	dir, err := os.MkdirTemp("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "sign.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+
+				line, err := bufio.NewReader(conn).ReadBytes('\n')
+				if err != nil {
+					return
+				}
+				var req signRequest
+				if err := json.Unmarshal(line, &req); err != nil {
+					return
+				}
+				if resp := handle(req); resp != "" {
+					conn.Write([]byte(resp + "\n"))
+				}
+			}()
+		}
+	}()
+	return path
+}
+
+func TestUnixSocketSigner(t *testing.T) {
+	creds := Credentials{AccessKey: "ak", SecretKey: "sk"}
+	hmac := NewHMACSigner(NewStaticProvider(creds))
+
+	// 데몬은 시크릿 키를 가진 HMACSigner로 서명합니다.
+	received := make(chan map[string]interface{}, 1)
+	path := startSignDaemon(t, func(req signRequest) string {
+		received <- req.Claims
+		token, err := hmac.Sign(req.Claims)
+		if err != nil {
+			t.Errorf("daemon Sign error: %v", err)
+		}
+		data, _ := json.Marshal(signResponse{Token: token})
+		return string(data)
+	})
+
+	gen := NewRestTokenGenWithSigner(NewUnixSocketSigner(path, time.Second), WithNonceFunc(func() string { return "nonce-1" }))
+	query := url.Values{"market": {"KRW-BTC"}, "states[]": {"wait", "watch"}}
+	token, err := gen.GenerateTokenWithQuery(query)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithQuery error: %v", err)
+	}
+
+	// 데몬에는 access_key 없이 요청별 클레임만 전달됩니다.
+	claims := <-received
+	if claims["nonce"] != "nonce-1" || claims["query_hash"] == nil || claims["query_hash_alg"] != "SHA512" || claims["access_key"] != nil {
+		t.Errorf("claims sent to daemon = %v", claims)
+	}
+
+	verified, err := VerifyToken(token, creds, query.Encode())
+	if err != nil {
+		t.Fatalf("VerifyToken error: %v", err)
+	}
+	if verified["access_key"] != "ak" || verified["nonce"] != "nonce-1" {
+		t.Errorf("verified claims = %v", verified)
+	}
+}
+
+func TestUnixSocketSignerErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		timeout time.Duration
+		wantErr string
+	}{
+		{name: "데몬 에러", reply: `{"error":"key locked"}`, wantErr: "signer error: key locked"},
+		{name: "빈 토큰", reply: `{"token":""}`, wantErr: "signer returned empty token"},
+		{name: "잘못된 응답", reply: `not json`, wantErr: "failed to parse sign response"},
+		{name: "응답 없이 종료", reply: "", wantErr: "failed to read sign response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := startSignDaemon(t, func(signRequest) string { return tt.reply })
+
+			_, err := NewUnixSocketSigner(path, time.Second).Sign(map[string]interface{}{"nonce": "n"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Sign error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("소켓 없음", func(t *testing.T) {
+		_, err := NewUnixSocketSigner(filepath.Join(t.TempDir(), "missing.sock"), time.Second).Sign(nil)
+		if err == nil || !strings.Contains(err.Error(), "failed to connect to signer") {
+			t.Errorf("Sign error = %v, want a connection error", err)
+		}
+	})
+
+	t.Run("시간 초과", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		path := startSignDaemon(t, func(signRequest) string {
+			<-block
+			return ""
+		})
+
+		start := time.Now()
+		_, err := NewUnixSocketSigner(path, 50*time.Millisecond).Sign(nil)
+		if err == nil || !strings.Contains(err.Error(), "failed to read sign response") {
+			t.Errorf("Sign error = %v, want a read timeout", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Sign returned after %v, want the timeout to apply", elapsed)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// VerifyToken이 반환하는 에러입니다.
+var (
+	ErrInvalidToken      = errors.New("invalid token signature") // 서명이 올바르지 않거나 토큰 형식이 잘못됨
+	ErrAccessKeyMismatch = errors.New("access key mismatch")     // access_key 클레임이 다름
+	ErrMissingNonce      = errors.New("missing nonce")           // nonce 클레임이 없음
+	ErrQueryHashMismatch = errors.New("query hash mismatch")     // query_hash가 요청과 다름
+)
+
+// VerifyToken은 토큰 생성기가 만든 JWT 토큰을 Upbit 서버와 같은 방식으로 검증합니다.
+// 모의 서버나 테스트에서 토큰을 확인할 때 사용합니다.
+//
+// token은 "Bearer " 접두사가 있어도 되며, rawQuery는 요청의 쿼리 문자열이나 form 본문을
+// 전송된 그대로 전달합니다. 서명(HS256, HS512), access_key, nonce의 존재,
+// query_hash(SHA512)를 확인하고 토큰의 클레임을 반환합니다.
+// 서명 검증 이후의 단계에서 실패하면 클레임과 함께 에러를 반환합니다.
+// nonce 재사용 여부는 확인하지 않습니다.
+func VerifyToken(token string, creds Credentials, rawQuery string) (map[string]interface{}, error) {
+	token = strings.TrimPrefix(token, "Bearer ")
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(creds.SecretKey), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS512"}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if accessKey, _ := claims["access_key"].(string); accessKey != creds.AccessKey {
+		return claims, ErrAccessKeyMismatch
+	}
+
+	if nonce, _ := claims["nonce"].(string); nonce == "" {
+		return claims, ErrMissingNonce
+	}
+
+	queryHash, _ := claims["query_hash"].(string)
+	if rawQuery == "" {
+		if queryHash != "" {
+			return claims, ErrQueryHashMismatch
+		}
+		return claims, nil
+	}
+
+	if alg, _ := claims["query_hash_alg"].(string); alg != "" && alg != "SHA512" {
+		return claims, fmt.Errorf("%w: unsupported query_hash_alg %s", ErrQueryHashMismatch, alg)
+	}
+	unescaped, err := url.QueryUnescape(rawQuery)
+	if err != nil {
+		return claims, fmt.Errorf("%w: %v", ErrQueryHashMismatch, err)
+	}
+	hash := sha512.Sum512([]byte(unescaped))
+	if queryHash != hex.EncodeToString(hash[:]) {
+		return claims, ErrQueryHashMismatch
+	}
+
+	return claims, nil
+}
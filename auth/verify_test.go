@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signTestToken은 method와 secret으로 claims를 서명한 토큰을 반환합니다.
+func signTestToken(t *testing.T, method jwt.SigningMethod, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// testQueryHash는 query의 SHA512 해시를 반환합니다.
+func testQueryHash(query string) string {
+	hash := sha512.Sum512([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestVerifyToken(t *testing.T) {
+	creds := Credentials{AccessKey: "ak", SecretKey: "sk"}
+	gen := NewRestTokenGen(creds)
+
+	query := url.Values{"market": {"KRW-BTC"}, "uuids[]": {"a", "b"}}
+	withQuery, err := gen.GenerateTokenWithQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withoutQuery, err := gen.GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := "market=KRW-BTC&side=bid"
+	withBody, err := gen.GenerateTokenWithBody(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		creds    Credentials
+		rawQuery string
+		want     error
+	}{
+		{name: "쿼리 포함", token: withQuery, creds: creds, rawQuery: query.Encode()},
+		{name: "이스케이프되지 않은 쿼리", token: withQuery, creds: creds, rawQuery: "market=KRW-BTC&uuids[]=a&uuids[]=b"},
+		{name: "쿼리 없음", token: withoutQuery, creds: creds},
+		{name: "Bearer 접두사 없음", token: strings.TrimPrefix(withQuery, "Bearer "), creds: creds, rawQuery: query.Encode()},
+		{name: "form 본문", token: withBody, creds: creds, rawQuery: body},
+		{
+			name:  "HS512 서명",
+			token: signTestToken(t, jwt.SigningMethodHS512, "sk", jwt.MapClaims{"access_key": "ak", "nonce": "n"}),
+			creds: creds,
+		},
+		{
+			name:     "query_hash_alg 생략",
+			token:    signTestToken(t, jwt.SigningMethodHS256, "sk", jwt.MapClaims{"access_key": "ak", "nonce": "n", "query_hash": testQueryHash("a=1")}),
+			creds:    creds,
+			rawQuery: "a=1",
+		},
+		{name: "빈 토큰", token: "", creds: creds, want: ErrInvalidToken},
+		{name: "형식이 잘못된 토큰", token: "Bearer not-a-jwt", creds: creds, want: ErrInvalidToken},
+		{name: "다른 비밀 키", token: withoutQuery, creds: Credentials{AccessKey: "ak", SecretKey: "other"}, want: ErrInvalidToken},
+		{
+			name:  "허용하지 않는 서명 알고리즘",
+			token: signTestToken(t, jwt.SigningMethodHS384, "sk", jwt.MapClaims{"access_key": "ak", "nonce": "n"}),
+			creds: creds,
+			want:  ErrInvalidToken,
+		},
+		{name: "다른 액세스 키", token: withoutQuery, creds: Credentials{AccessKey: "other", SecretKey: "sk"}, want: ErrAccessKeyMismatch},
+		{
+			name:  "nonce 없음",
+			token: signTestToken(t, jwt.SigningMethodHS256, "sk", jwt.MapClaims{"access_key": "ak"}),
+			creds: creds,
+			want:  ErrMissingNonce,
+		},
+		{name: "다른 쿼리", token: withQuery, creds: creds, rawQuery: "market=KRW-ETH", want: ErrQueryHashMismatch},
+		{name: "쿼리가 있는 토큰에 쿼리 없음", token: withQuery, creds: creds, want: ErrQueryHashMismatch},
+		{name: "쿼리가 없는 토큰에 쿼리 있음", token: withoutQuery, creds: creds, rawQuery: "market=KRW-BTC", want: ErrQueryHashMismatch},
+		{
+			name:     "지원하지 않는 query_hash_alg",
+			token:    signTestToken(t, jwt.SigningMethodHS256, "sk", jwt.MapClaims{"access_key": "ak", "nonce": "n", "query_hash": testQueryHash("a=1"), "query_hash_alg": "SHA256"}),
+			creds:    creds,
+			rawQuery: "a=1",
+			want:     ErrQueryHashMismatch,
+		},
+		{name: "잘못된 이스케이프", token: withQuery, creds: creds, rawQuery: "market=%zz", want: ErrQueryHashMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := VerifyToken(tt.token, tt.creds, tt.rawQuery)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("VerifyToken error: %v", err)
+				}
+				if claims["access_key"] != tt.creds.AccessKey {
+					t.Errorf("access_key claim = %v", claims["access_key"])
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyToken error = %v, want %v", err, tt.want)
+			}
+			// 서명 검증 이후 단계의 실패는 클레임을 함께 반환합니다.
+			if tt.want != ErrInvalidToken && claims == nil {
+				t.Error("VerifyToken returned nil claims after signature verification")
+			}
+		})
+	}
+}
+
+func TestVerifyTokenFixedNonce(t *testing.T) {
+	creds := Credentials{AccessKey: "ak", SecretKey: "sk"}
+	gen := NewRestTokenGen(creds, WithNonceFunc(func() string { return "fixed-nonce" }))
+
+	first, err := gen.GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := gen.GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("tokens with a fixed nonce differ: %s, %s", first, second)
+	}
+
+	claims, err := VerifyToken(first, creds, "")
+	if err != nil {
+		t.Fatalf("VerifyToken error: %v", err)
+	}
+	if claims["nonce"] != "fixed-nonce" {
+		t.Errorf("nonce claim = %v, want fixed-nonce", claims["nonce"])
+	}
+}
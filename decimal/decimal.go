@@ -0,0 +1,429 @@
+// Package decimal은 가격, 수량, 잔고를 정밀도 손실 없이 다루기 위한 십진수 타입을 제공합니다.
+//
+// Upbit API는 금액과 수량을 문자열("0.00012345") 또는 JSON 숫자로 반환합니다.
+// float64로 변환하면 사토시 단위의 수량이나 큰 원화 금액에서 오차가 생기므로,
+// REST API와 웹소켓 응답의 숫자 필드는 모두 Decimal로 파싱됩니다.
+//
+//	balance := decimal.RequireFromString("0.1")
+//	fee := decimal.RequireFromString("0.0005")
+//	total := balance.Sub(balance.Mul(fee)) // 0.09995
+//
+// 기존 float64 또는 string 필드를 사용하던 코드는 Float64, String 메서드로 변환할 수 있습니다.
+//
+// 요청 파라미터(주문의 가격과 수량, 입출금 금액)는 문자열로 유지됩니다. 지정하지 않은 값은 요청에서
+// 제외되어야 하지만 Decimal의 영값은 0과 구분할 수 없기 때문이며, Decimal 값은 String으로 전달합니다.
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DivisionPrecision은 Div가 사용하는 소수점 이하 자릿수입니다.
+var DivisionPrecision int32 = 16
+
+// Zero는 0을 나타내는 Decimal입니다.
+var Zero = Decimal{}
+
+// Decimal은 value × 10^-scale 형태로 표현되는 고정 소수점 십진수입니다.
+// 영값(Decimal{})은 0이며, 모든 연산은 새 값을 반환하므로 여러 고루틴에서 공유해도 안전합니다.
+type Decimal struct {
+	value *big.Int // 정수 값 (nil이면 0)
+	scale int32    // 소수점 이하 자릿수 (0 이상)
+}
+
+// New는 value × 10^-scale 값을 가진 Decimal을 생성합니다.
+// scale이 음수이면 value × 10^-scale로 정수 값을 생성합니다.
+func New(value int64, scale int32) Decimal {
+	v := big.NewInt(value)
+	if scale < 0 {
+		return Decimal{value: v.Mul(v, pow10(-scale))}
+	}
+	return Decimal{value: v, scale: scale}
+}
+
+// NewFromInt는 정수 값을 가진 Decimal을 생성합니다.
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat는 float64 값을 가진 Decimal을 생성합니다.
+// f를 표현하는 가장 짧은 십진수 문자열을 사용하므로 0.1은 정확히 0.1이 됩니다.
+// NaN과 무한대는 0을 반환합니다.
+func NewFromFloat(f float64) Decimal {
+	d, err := NewFromString(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Zero
+	}
+	return d
+}
+
+// maxExponent는 NewFromString이 허용하는 10의 지수 절댓값의 최댓값입니다.
+// "1e999999999"처럼 큰 지수로 인해 큰 메모리를 할당하지 않도록 제한합니다.
+const maxExponent = 1000
+
+// NewFromString은 "123.45", "-0.001", "1.5e-8" 형식의 문자열을 파싱합니다.
+// 지수(소수점 이하 자릿수 포함)의 절댓값이 maxExponent를 넘으면 에러를 반환합니다.
+func NewFromString(s string) (Decimal, error) {
+	str := s
+
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("decimal: cannot parse %q", s)
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	digits := intPart + fracPart
+	if !validDigits(digits) {
+		return Zero, fmt.Errorf("decimal: cannot parse %q", s)
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("decimal: cannot parse %q", s)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxExponent || scale < -maxExponent {
+		return Zero, fmt.Errorf("decimal: exponent out of range %q", s)
+	}
+	if scale < 0 {
+		value.Mul(value, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+// RequireFromString은 NewFromString과 같지만 파싱에 실패하면 패닉이 발생합니다.
+// 상수 값을 초기화할 때 사용합니다.
+func RequireFromString(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// validDigits는 s가 부호 하나와 숫자로만 이루어져 있는지 확인합니다.
+func validDigits(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// pow10은 10^n을 반환합니다.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// int는 정수 값을 반환합니다. 영값이면 0을 반환합니다.
+func (d Decimal) int() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescale은 소수점 이하 자릿수를 scale로 늘린 정수 값을 반환합니다.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// align은 두 값을 같은 소수점 이하 자릿수의 정수 값으로 변환합니다.
+func align(a, b Decimal) (x, y *big.Int, scale int32) {
+	scale = a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Add는 d + d2를 반환합니다.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{value: new(big.Int).Add(x, y), scale: scale}
+}
+
+// Sub는 d - d2를 반환합니다.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	x, y, scale := align(d, d2)
+	return Decimal{value: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Mul은 d × d2를 반환합니다.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.int(), d2.int()), scale: d.scale + d2.scale}
+}
+
+// Div는 d ÷ d2를 소수점 이하 DivisionPrecision 자리로 반올림해 반환합니다.
+// d2가 0이면 패닉이 발생합니다.
+func (d Decimal) Div(d2 Decimal) Decimal {
+	return d.DivRound(d2, DivisionPrecision)
+}
+
+// DivRound는 d ÷ d2를 소수점 이하 places 자리로 반올림(0에서 먼 쪽)해 반환합니다.
+// d2가 0이면 패닉이 발생합니다.
+func (d Decimal) DivRound(d2 Decimal, places int32) Decimal {
+	if d2.IsZero() {
+		panic("decimal: division by zero")
+	}
+	if places < 0 {
+		places = 0
+	}
+
+	// d ÷ d2 × 10^places = d.value × 10^(places - d.scale + d2.scale) ÷ d2.value
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(d2.int())
+	if k := int64(places) - int64(d.scale) + int64(d2.scale); k >= 0 {
+		num.Mul(num, pow10(int32(k)))
+	} else {
+		den.Mul(den, pow10(int32(-k)))
+	}
+	return Decimal{value: quoRound(num, den), scale: places}
+}
+
+// quoRound는 num ÷ den을 0에서 먼 쪽으로 반올림한 정수를 반환합니다.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// |2r| >= |den|이면 절대값을 1 늘립니다.
+	if new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Round는 소수점 이하 places 자리로 반올림(0에서 먼 쪽)한 값을 반환합니다.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	return Decimal{value: quoRound(d.int(), pow10(d.scale-places)), scale: places}
+}
+
+// Truncate는 소수점 이하 places 자리 아래를 버린 값을 반환합니다.
+// 주문 수량을 호가 단위에 맞출 때 사용합니다.
+func (d Decimal) Truncate(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	return Decimal{value: new(big.Int).Quo(d.int(), pow10(d.scale-places)), scale: places}
+}
+
+// Neg는 -d를 반환합니다.
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs는 d의 절대값을 반환합니다.
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Sign은 d가 음수이면 -1, 0이면 0, 양수이면 1을 반환합니다.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero는 d가 0인지 확인합니다.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsPositive는 d가 0보다 큰지 확인합니다.
+func (d Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+// IsNegative는 d가 0보다 작은지 확인합니다.
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// Cmp는 d < d2이면 -1, d == d2이면 0, d > d2이면 1을 반환합니다.
+// 소수점 이하 자릿수는 비교에 영향을 주지 않습니다("1.0"과 "1"은 같습니다).
+func (d Decimal) Cmp(d2 Decimal) int {
+	x, y, _ := align(d, d2)
+	return x.Cmp(y)
+}
+
+// Equal은 d와 d2가 같은 값인지 확인합니다.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// GreaterThan은 d > d2인지 확인합니다.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// GreaterThanOrEqual은 d >= d2인지 확인합니다.
+func (d Decimal) GreaterThanOrEqual(d2 Decimal) bool {
+	return d.Cmp(d2) >= 0
+}
+
+// LessThan은 d < d2인지 확인합니다.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// LessThanOrEqual은 d <= d2인지 확인합니다.
+func (d Decimal) LessThanOrEqual(d2 Decimal) bool {
+	return d.Cmp(d2) <= 0
+}
+
+// Min은 인자 중 가장 작은 값을 반환합니다.
+func Min(first Decimal, rest ...Decimal) Decimal {
+	m := first
+	for _, d := range rest {
+		if d.LessThan(m) {
+			m = d
+		}
+	}
+	return m
+}
+
+// Max는 인자 중 가장 큰 값을 반환합니다.
+func Max(first Decimal, rest ...Decimal) Decimal {
+	m := first
+	for _, d := range rest {
+		if d.GreaterThan(m) {
+			m = d
+		}
+	}
+	return m
+}
+
+// Sum은 인자의 합을 반환합니다.
+func Sum(values ...Decimal) Decimal {
+	total := Zero
+	for _, d := range values {
+		total = total.Add(d)
+	}
+	return total
+}
+
+// String은 지수 표기 없이 불필요한 0을 제거한 문자열을 반환합니다 (예: "0.00012345").
+// 주문 요청의 가격과 수량 파라미터에 그대로 사용할 수 있습니다.
+func (d Decimal) String() string {
+	s := d.format(d.scale)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed는 소수점 이하 places 자리로 반올림한 문자열을 반환합니다.
+// 자릿수가 부족하면 0을 채웁니다 (예: StringFixed(2) → "1.50").
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	r := d.Round(places)
+	return Decimal{value: r.rescale(places), scale: places}.format(places)
+}
+
+// format은 소수점 이하 scale 자리의 문자열을 반환합니다.
+func (d Decimal) format(scale int32) string {
+	v := d.int()
+	digits := new(big.Int).Abs(v).String()
+	if scale > 0 {
+		if pad := int(scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Float64는 d와 가장 가까운 float64 값을 반환합니다.
+// 기존 float64 필드를 사용하던 코드와의 호환을 위해 제공되며, 정밀도가 손실될 수 있습니다.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// IntPart는 소수점 이하를 버린 정수 부분을 반환합니다.
+func (d Decimal) IntPart() int64 {
+	return d.Truncate(0).int().Int64()
+}
+
+// MarshalJSON은 d를 JSON 문자열("123.45")로 인코딩합니다.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON은 JSON 문자열("123.45")과 숫자(123.45) 모두를 파싱합니다.
+// null은 값을 변경하지 않으며, 빈 문자열은 0으로 파싱합니다.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("decimal: cannot parse %s", s)
+		}
+		if unquoted == "" {
+			*d = Zero
+			return nil
+		}
+		s = unquoted
+	}
+
+	parsed, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText는 d를 문자열로 인코딩합니다.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText는 문자열을 파싱합니다.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := NewFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
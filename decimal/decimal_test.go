@@ -0,0 +1,189 @@
+package decimal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "정수", input: "123", want: "123"},
+		{name: "소수", input: "123.45", want: "123.45"},
+		{name: "음수", input: "-0.001", want: "-0.001"},
+		{name: "양수 부호", input: "+1.5", want: "1.5"},
+		{name: "뒤쪽 0 제거", input: "1.500", want: "1.5"},
+		{name: "음수 지수", input: "1.5e-8", want: "0.000000015"},
+		{name: "양수 지수", input: "1.5E3", want: "1500"},
+		{name: "정수부 생략", input: ".5", want: "0.5"},
+		{name: "지수 상한", input: "1e1000", want: "1" + strings.Repeat("0", 1000)},
+		{name: "지수 하한", input: "1e-1000", want: "0." + strings.Repeat("0", 999) + "1"},
+		{name: "지수 상한 초과", input: "1e1001", wantErr: true},
+		{name: "지수 하한 초과", input: "1e-1001", wantErr: true},
+		{name: "큰 지수", input: "1e999999999", wantErr: true},
+		{name: "소수점 이하 자릿수와 합친 지수 초과", input: "0.1e-1000", wantErr: true},
+		{name: "int32 범위를 넘는 지수", input: "1e9999999999", wantErr: true},
+		{name: "빈 문자열", input: "", wantErr: true},
+		{name: "부호만", input: "-", wantErr: true},
+		{name: "문자 포함", input: "12a", wantErr: true},
+		{name: "소수점 두 개", input: "1.2.3", wantErr: true},
+		{name: "빈 지수", input: "1e", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromString(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewFromString(%q) = %s, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFromString(%q) error: %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("NewFromString(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	d := RequireFromString
+
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{name: "Add", got: d("0.1").Add(d("0.2")), want: "0.3"},
+		{name: "Sub", got: d("0.1").Sub(d("0.1").Mul(d("0.0005"))), want: "0.09995"},
+		{name: "Mul", got: d("1.5").Mul(d("-2")), want: "-3"},
+		{name: "Div", got: d("1").Div(d("3")), want: "0.3333333333333333"},
+		{name: "DivRound 반올림", got: d("2").DivRound(d("3"), 2), want: "0.67"},
+		{name: "DivRound 음수", got: d("-2").DivRound(d("3"), 2), want: "-0.67"},
+		{name: "Round 0에서 먼 쪽", got: d("-1.25").Round(1), want: "-1.3"},
+		{name: "Round 자릿수 충분", got: d("1.2").Round(4), want: "1.2"},
+		{name: "Truncate", got: d("0.123456789").Truncate(8), want: "0.12345678"},
+		{name: "Truncate 음수", got: d("-1.99").Truncate(0), want: "-1"},
+		{name: "Neg", got: d("1.5").Neg(), want: "-1.5"},
+		{name: "Abs", got: d("-1.5").Abs(), want: "1.5"},
+		{name: "Min", got: Min(d("3"), d("-1"), d("2")), want: "-1"},
+		{name: "Max", got: Max(d("3"), d("-1"), d("2")), want: "3"},
+		{name: "Sum", got: Sum(d("0.1"), d("0.2"), d("0.3")), want: "0.6"},
+		{name: "영값", got: Zero.Add(Decimal{}), want: "0"},
+		{name: "New 음수 scale", got: New(15, -2), want: "1500"},
+		{name: "NewFromFloat", got: NewFromFloat(0.1), want: "0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("got %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDivByZeroPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Div by zero did not panic")
+		}
+	}()
+	NewFromInt(1).Div(Zero)
+}
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "1", want: 0},
+		{a: "0.0001", b: "0.001", want: -1},
+		{a: "-1", b: "-2", want: 1},
+		{a: "0", b: "-0", want: 0},
+	}
+
+	for _, tt := range tests {
+		a, b := RequireFromString(tt.a), RequireFromString(tt.b)
+		if got := a.Cmp(b); got != tt.want {
+			t.Errorf("Cmp(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := a.Equal(b); got != (tt.want == 0) {
+			t.Errorf("Equal(%s, %s) = %v", tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestStringFixed(t *testing.T) {
+	tests := []struct {
+		input  string
+		places int32
+		want   string
+	}{
+		{input: "1.5", places: 2, want: "1.50"},
+		{input: "1.005", places: 2, want: "1.01"},
+		{input: "-0.004", places: 2, want: "0.00"},
+		{input: "12", places: 0, want: "12"},
+		{input: "0.00012345", places: 8, want: "0.00012345"},
+	}
+
+	for _, tt := range tests {
+		if got := RequireFromString(tt.input).StringFixed(tt.places); got != tt.want {
+			t.Errorf("StringFixed(%s, %d) = %s, want %s", tt.input, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "문자열", input: `"0.00012345"`, want: "0.00012345"},
+		{name: "숫자", input: `123.45`, want: "123.45"},
+		{name: "지수 표기 숫자", input: `1e-8`, want: "0.00000001"},
+		{name: "빈 문자열", input: `""`, want: "0"},
+		{name: "null은 값을 유지", input: `null`, want: "7"},
+		{name: "잘못된 문자열", input: `"abc"`, wantErr: true},
+		{name: "범위를 넘는 지수", input: `1e-5000`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewFromInt(7)
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %s, want error", tt.input, d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error: %v", tt.input, err)
+			}
+			if d.String() != tt.want {
+				t.Errorf("Unmarshal(%s) = %s, want %s", tt.input, d, tt.want)
+			}
+		})
+	}
+
+	data, err := json.Marshal(struct {
+		Price Decimal `json:"price"`
+	}{Price: RequireFromString("-1.50")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"price":"-1.5"}` {
+		t.Errorf("Marshal = %s", data)
+	}
+}
@@ -2,11 +2,17 @@
 // 이 패키지는 순환 참조를 방지하기 위해 별도로 분리되었습니다.
 package client
 
+import (
+	"context"
+	"net/url"
+)
+
 // RestClient는 Upbit REST API와의 기본적인 HTTP 통신을 위한 인터페이스입니다.
 type RestClient interface {
 	// Get은 지정된 경로로 GET 요청을 보내고 응답을 바이트 슬라이스로 반환합니다.
 	// path는 요청할 API 엔드포인트 경로이며, params는 쿼리 파라미터입니다.
-	Get(path string, params map[string]string) ([]byte, error)
+	// 배열 파라미터는 "states[]"처럼 같은 키를 반복해서 지정합니다.
+	Get(path string, params url.Values) ([]byte, error)
 
 	// Post는 지정된 경로로 POST 요청을 보내고 응답을 바이트 슬라이스로 반환합니다.
 	// path는 요청할 API 엔드포인트 경로이며, body는 요청 본문입니다.
@@ -14,5 +20,15 @@ type RestClient interface {
 
 	// Delete는 지정된 경로로 DELETE 요청을 보내고 응답을 바이트 슬라이스로 반환합니다.
 	// path는 요청할 API 엔드포인트 경로이며, params는 쿼리 파라미터입니다.
-	Delete(path string, params map[string]string) ([]byte, error)
+	// 배열 파라미터는 "states[]"처럼 같은 키를 반복해서 지정합니다.
+	Delete(path string, params url.Values) ([]byte, error)
+
+	// GetCtx는 Get과 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+	GetCtx(ctx context.Context, path string, params url.Values) ([]byte, error)
+
+	// PostCtx는 Post와 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+	PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error)
+
+	// DeleteCtx는 Delete와 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+	DeleteCtx(ctx context.Context, path string, params url.Values) ([]byte, error)
 }
@@ -0,0 +1,35 @@
+package client
+
+import "context"
+
+// contextKey는 이 패키지에서 컨텍스트 값을 저장할 때 사용하는 키 타입입니다.
+type contextKey int
+
+const (
+	idempotentKey contextKey = iota // 멱등 요청 표시
+	publicKey                       // 공개 요청 표시
+)
+
+// WithIdempotent는 요청이 여러 번 전송되어도 안전하다는 표시를 ctx에 추가합니다.
+// REST 클라이언트는 이 표시가 있는 POST, DELETE 요청만 재시도합니다.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey, true)
+}
+
+// IsIdempotent는 ctx에 멱등 요청 표시가 있는지 확인합니다.
+func IsIdempotent(ctx context.Context) bool {
+	v, _ := ctx.Value(idempotentKey).(bool)
+	return v
+}
+
+// WithPublic은 요청이 인증 없이 호출할 수 있는 공개 API라는 표시를 ctx에 추가합니다.
+// REST 클라이언트는 이 표시가 있는 요청에 인증 토큰을 붙이지 않습니다.
+func WithPublic(ctx context.Context) context.Context {
+	return context.WithValue(ctx, publicKey, true)
+}
+
+// IsPublic은 ctx에 공개 요청 표시가 있는지 확인합니다.
+func IsPublic(ctx context.Context) bool {
+	v, _ := ctx.Value(publicKey).(bool)
+	return v
+}
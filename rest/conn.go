@@ -1,13 +1,17 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/hysuki/go-upbit/auth"
+	restclient "github.com/hysuki/go-upbit/rest/client"
 	"github.com/hysuki/go-upbit/rest/exchange"
 	"github.com/hysuki/go-upbit/rest/quotation"
 )
@@ -26,53 +30,153 @@ type TokenGenerator interface {
 
 // Client는 Upbit REST API와 상호작용하기 위한 메서드를 정의하는 인터페이스입니다.
 type Client interface {
-	Get(path string, params map[string]string) ([]byte, error)    // GET 요청 수행
-	Post(path string, body interface{}) ([]byte, error)           // POST 요청 수행
-	Delete(path string, params map[string]string) ([]byte, error) // DELETE 요청 수행
-	GetExchange() *exchange.Exchange                              // 거래소 API 객체 반환
-	GetQuotation() *quotation.Quotation                           // 시세 조회 API 객체 반환
+	Get(path string, params url.Values) ([]byte, error)    // GET 요청 수행
+	Post(path string, body interface{}) ([]byte, error)    // POST 요청 수행
+	Delete(path string, params url.Values) ([]byte, error) // DELETE 요청 수행
+	GetExchange() *exchange.Exchange                       // 거래소 API 객체 반환
+	GetQuotation() *quotation.Quotation                    // 시세 조회 API 객체 반환
+	RateLimiter() *RateLimiter                             // 요청 수 제한기 반환
+
+	GetCtx(ctx context.Context, path string, params url.Values) ([]byte, error)    // 컨텍스트를 사용하는 GET 요청 수행
+	PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error)    // 컨텍스트를 사용하는 POST 요청 수행
+	DeleteCtx(ctx context.Context, path string, params url.Values) ([]byte, error) // 컨텍스트를 사용하는 DELETE 요청 수행
 }
 
-// APIError는 Upbit API에서 반환하는 에러 정보를 나타냅니다.
-type APIError struct {
-	Name    string `json:"name"`    // 에러 이름
-	Message string `json:"message"` // 에러 메시지
+// client는 Upbit REST API 클라이언트입니다.
+type client struct {
+	httpClient  *http.Client         // HTTP 클라이언트
+	tokenGen    TokenGenerator       // 토큰 생성기
+	baseURL     string               // API 기본 URL
+	limiter     *RateLimiter         // 요청 수 제한기
+	retry       RetryPolicy          // 재시도 정책
+	middlewares []Middleware         // 요청/응답 미들웨어
+	roundTrip   RoundTrip            // 미들웨어가 적용된 요청 실행 함수
+	Exchange    *exchange.Exchange   // 거래소 API 객체
+	Quotation   *quotation.Quotation // 시세 조회 API 객체
 }
 
-// Error는 에러 메시지를 반환합니다.
-func (e *APIError) Error() string {
-	return e.Message
+// ClientOption은 REST API 클라이언트의 설정을 변경하는 함수 타입입니다.
+type ClientOption func(*client)
+
+// WithRateLimitMode는 요청 수 제한에 도달했을 때의 동작 방식을 설정하는 옵션을 반환합니다.
+// 기본값은 RateLimitBlock입니다.
+func WithRateLimitMode(mode RateLimitMode) ClientOption {
+	return func(c *client) {
+		c.limiter.SetMode(mode)
+	}
 }
 
-// ErrorResponse는 API 에러 응답을 나타냅니다.
-type ErrorResponse struct {
-	Error APIError `json:"error"` // API 에러 정보
+// WithRetryPolicy는 요청 실패 시의 재시도 정책을 설정하는 옵션을 반환합니다.
+// 기본값은 NoRetry입니다.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retry = policy
+	}
 }
 
-// client는 Upbit REST API 클라이언트입니다.
-type client struct {
-	httpClient *http.Client         // HTTP 클라이언트
-	tokenGen   TokenGenerator       // 토큰 생성기
-	baseURL    string               // API 기본 URL
-	Exchange   *exchange.Exchange   // 거래소 API 객체
-	Quotation  *quotation.Quotation // 시세 조회 API 객체
+// WithBaseURL은 REST API 기본 URL을 설정하는 옵션을 반환합니다.
+// 기본값은 BaseURL이며, 테스트용 서버나 프록시를 사용할 때 변경합니다.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient는 요청에 사용할 HTTP 클라이언트를 설정하는 옵션을 반환합니다.
+// 프록시, TLS 설정, 타임아웃 등은 httpClient의 Transport로 지정합니다.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
 }
 
 // NewClient는 새로운 Upbit REST API 클라이언트를 생성합니다.
-// tokenGen은 API 인증에 사용할 토큰 생성기입니다.
-func NewClient(tokenGen TokenGenerator) *client {
+// tokenGen은 API 인증에 사용할 토큰 생성기이며, opts로 클라이언트 설정을 지정할 수 있습니다.
+// tokenGen이 nil이면 시세 조회 API만 사용할 수 있고, 인증이 필요한 요청은
+// auth.ErrNotAuthenticated를 반환합니다.
+func NewClient(tokenGen TokenGenerator, opts ...ClientOption) *client {
 	c := &client{
 		httpClient: &http.Client{},
 		tokenGen:   tokenGen,
 		baseURL:    BaseURL,
+		limiter:    NewRateLimiter(RateLimitBlock),
+		retry:      NoRetry,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.roundTrip = chain(c.middlewares, c.httpClient.Do)
 	c.Exchange = exchange.NewExchange(c)
 	c.Quotation = quotation.NewQuotation(c)
 	return c
 }
 
+// requestBuilder는 한 번의 시도에 사용할 HTTP 요청을 생성하는 함수입니다.
+// 재시도할 때마다 새 nonce로 서명된 토큰이 필요하므로 시도마다 호출됩니다.
+type requestBuilder func(ctx context.Context) (*http.Request, error)
+
+// do는 요청 수 제한을 확인한 뒤 HTTP 요청을 실행하고 응답을 처리합니다.
+// path는 요청 그룹을 결정하는 데 사용되는 API 경로이며,
+// 재시도 정책에 따라 실패한 요청을 다시 보냅니다.
+func (c *client) do(ctx context.Context, method, path string, build requestBuilder) ([]byte, error) {
+	if c.tokenGen == nil && !restclient.IsPublic(ctx) {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	retryable := c.retry.canRetry(ctx, method)
+	ctx = withRateLimitGroup(ctx, c.limiter.Group(method, path))
+
+	for attempt := 1; ; attempt++ {
+		lastAttempt := !retryable || attempt >= c.retry.MaxAttempts
+
+		if err := c.limiter.Wait(ctx, method, path); err != nil {
+			return nil, err
+		}
+
+		req, err := build(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// HTTP 요청 실행
+		sentAt := time.Now()
+		resp, err := c.roundTrip(req)
+		if err != nil {
+			c.report(req, nil, err)
+			if lastAttempt || ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to execute HTTP request: %w", err)
+			}
+			if err := sleep(ctx, c.retry.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		rr := c.limiter.Update(method, path, resp.Header.Get(RemainingReqHeader))
+		captureResponse(ctx, resp, rr, attempt, sentAt)
+
+		if !lastAttempt && c.retry.RetryableStatus[resp.StatusCode] {
+			c.report(req, rr, newStatusError(resp.StatusCode, nil))
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if err := sleep(ctx, c.retry.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		body, err := c.handleResponse(resp, rr)
+		resp.Body.Close()
+		c.report(req, rr, err)
+		return body, err
+	}
+}
+
 // handleResponse는 API 응답을 처리하고 에러가 있는 경우 이를 반환합니다.
-func (c *client) handleResponse(resp *http.Response) ([]byte, error) {
+// 반환되는 에러는 *APIError이며 상태 코드와 잔여 요청 수 정보 rr을 포함합니다.
+func (c *client) handleResponse(resp *http.Response, rr *RemainingReq) ([]byte, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
@@ -80,128 +184,125 @@ func (c *client) handleResponse(resp *http.Response) ([]byte, error) {
 
 	// 응답이 에러인지 확인
 	var errResp ErrorResponse
-	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
-		return nil, &errResp.Error
+	if err := json.Unmarshal(body, &errResp); err == nil && (errResp.Error.Message != "" || errResp.Error.Name != "") {
+		apiErr := errResp.Error
+		apiErr.StatusCode = resp.StatusCode
+		apiErr.RemainingReq = rr
+		return nil, &apiErr
+	}
+
+	// 본문으로 에러를 판별할 수 없는 실패 응답
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := newStatusError(resp.StatusCode, body)
+		apiErr.RemainingReq = rr
+		return nil, apiErr
 	}
 
 	return body, nil
 }
 
 // Get은 지정된 경로로 GET 요청을 보내고 응답을 반환합니다.
-func (c *client) Get(path string, params map[string]string) ([]byte, error) {
-	req, err := http.NewRequest("GET", c.baseURL+path, nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *client) Get(path string, params url.Values) ([]byte, error) {
+	return c.GetCtx(context.Background(), path, params)
+}
 
-	// 쿼리 파라미터 추가
-	q := req.URL.Query()
-	for key, value := range params {
-		q.Add(key, value)
-	}
-	req.URL.RawQuery = q.Encode()
+// GetCtx는 Get과 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+func (c *client) GetCtx(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	return c.doQuery(ctx, "GET", path, params)
+}
 
-	// 인증 토큰 생성 시 쿼리 파라미터 전달
-	token, err := c.tokenGen.GenerateTokenWithQuery(req.URL.Query())
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", token)
+// Post는 지정된 경로로 POST 요청을 보내고 응답을 반환합니다.
+func (c *client) Post(path string, body interface{}) ([]byte, error) {
+	return c.PostCtx(context.Background(), path, body)
+}
 
-	// HTTP 요청 실행
-	resp, err := c.httpClient.Do(req)
+// PostCtx는 Post와 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+func (c *client) PostCtx(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	values, err := encodeBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute HTTP request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return c.handleResponse(resp)
-}
 
-// Post는 지정된 경로로 POST 요청을 보내고 응답을 반환합니다.
-func (c *client) Post(path string, body interface{}) ([]byte, error) {
-	// body를 map[string]interface{}로 변환
-	var bodyMap map[string]interface{}
+	// 토큰의 query_hash와 본문이 같은 문자열을 사용하도록 한 번만 인코딩
+	encodedBody := values.Encode()
 
-	// 1. 이미 map[string]interface{}인 경우
-	if m, ok := body.(map[string]interface{}); ok {
-		bodyMap = m
-	} else {
-		// 2. 구조체를 JSON으로 마샬링 후 다시 map으로 언마샬링
-		jsonBytes, err := json.Marshal(body)
+	return c.do(ctx, "POST", path, func(ctx context.Context) (*http.Request, error) {
+		// 요청 생성 (body는 인코딩된 form 데이터)
+		req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, strings.NewReader(encodedBody))
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal body: %w", err)
+			return nil, err
 		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-		if err := json.Unmarshal(jsonBytes, &bodyMap); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal body to map: %w", err)
+		if err := c.authorize(ctx, req, values); err != nil {
+			return nil, err
 		}
-	}
-
-	// url.Values로 변환
-	values := make(url.Values)
-	for k, v := range bodyMap {
-		values.Add(k, fmt.Sprintf("%v", v))
-	}
-
-	// url.Values로 인코딩된 문자열 생성
-	encodedBody := values.Encode()
+		return req, nil
+	})
+}
 
-	// 동일한 values로 토큰 생성
-	token, err := c.tokenGen.GenerateTokenWithQuery(values)
-	if err != nil {
-		return nil, err
-	}
+// Delete는 지정된 경로로 DELETE 요청을 보내고 응답을 반환합니다.
+func (c *client) Delete(path string, params url.Values) ([]byte, error) {
+	return c.DeleteCtx(context.Background(), path, params)
+}
 
-	// 요청 생성 (body는 인코딩된 form 데이터)
-	req, err := http.NewRequest("POST", c.baseURL+path, strings.NewReader(encodedBody))
-	if err != nil {
-		return nil, err
-	}
+// DeleteCtx는 Delete와 같지만 ctx가 취소되거나 기한이 지나면 요청을 중단합니다.
+func (c *client) DeleteCtx(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	return c.doQuery(ctx, "DELETE", path, params)
+}
 
-	// 헤더 설정 (Content-Type을 form 데이터로 변경)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Authorization", token)
+// doQuery는 params를 쿼리 문자열로 사용하는 GET, DELETE 요청을 실행합니다.
+func (c *client) doQuery(ctx context.Context, method, path string, params url.Values) ([]byte, error) {
+	// 요청 URL과 query_hash가 같은 문자열을 사용하도록 한 번만 인코딩
+	rawQuery := params.Encode()
 
-	// HTTP 요청 실행
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return c.do(ctx, method, path, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.URL.RawQuery = rawQuery
 
-	return c.handleResponse(resp)
+		if err := c.authorize(ctx, req, params); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
 }
 
-// Delete는 지정된 경로로 DELETE 요청을 보내고 응답을 반환합니다.
-func (c *client) Delete(path string, params map[string]string) ([]byte, error) {
-	req, err := http.NewRequest("DELETE", c.baseURL+path, nil)
-	if err != nil {
-		return nil, err
+// authorize는 values로 query_hash를 계산한 인증 토큰을 req의 Authorization 헤더에 설정합니다.
+// GET, POST, DELETE 모두 같은 방식으로 서명하며, 공개 API 요청은 토큰 없이 보냅니다.
+func (c *client) authorize(ctx context.Context, req *http.Request, values url.Values) error {
+	if restclient.IsPublic(ctx) {
+		return nil
 	}
 
-	// 쿼리 파라미터 추가
-	q := req.URL.Query()
-	for key, value := range params {
-		q.Add(key, value)
+	var token string
+	var err error
+	if gen, ok := c.tokenGen.(GroupTokenGenerator); ok {
+		// 키 풀처럼 요청 그룹의 잔여 요청 수로 키를 고르는 생성기입니다.
+		token, err = gen.GenerateTokenForGroup(rateLimitGroup(ctx), values)
+	} else {
+		token, err = c.tokenGen.GenerateTokenWithQuery(values)
 	}
-	req.URL.RawQuery = q.Encode()
-
-	// 인증 토큰 생성 및 헤더 추가
-	token, err := c.tokenGen.GenerateToken()
 	if err != nil {
-		return nil, err
+		return err
 	}
+
 	req.Header.Set("Authorization", token)
+	return nil
+}
 
-	// HTTP 요청 실행
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+// report는 토큰 생성기가 TokenFeedback을 구현하면 req의 토큰과 요청 결과를 전달합니다.
+// 인증 토큰 없이 보낸 공개 API 요청은 전달하지 않습니다.
+func (c *client) report(req *http.Request, rr *RemainingReq, err error) {
+	feedback, ok := c.tokenGen.(TokenFeedback)
+	if !ok {
+		return
+	}
+	if token := req.Header.Get("Authorization"); token != "" {
+		feedback.Report(token, rr, err)
 	}
-	defer resp.Body.Close()
-
-	return c.handleResponse(resp)
 }
 
 // GetExchange는 거래소 API 관련 기능을 제공하는 Exchange 객체를 반환합니다.
@@ -213,3 +314,8 @@ func (c *client) GetExchange() *exchange.Exchange {
 func (c *client) GetQuotation() *quotation.Quotation {
 	return c.Quotation
 }
+
+// RateLimiter는 그룹별 잔여 요청 수를 추적하는 요청 수 제한기를 반환합니다.
+func (c *client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
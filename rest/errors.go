@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode는 Upbit API가 반환하는 에러 이름입니다.
+// ErrorCode는 error를 구현하므로 errors.Is의 비교 대상으로 사용할 수 있습니다.
+//
+//	if errors.Is(err, rest.ErrInsufficientFundsBid) {
+//		// 매수 가능 금액 부족
+//	}
+type ErrorCode string
+
+// Error는 에러 이름을 반환합니다.
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// Upbit API 문서에 정의된 에러 이름들입니다.
+const (
+	// 400 Bad Request
+	ErrCreateAskError               ErrorCode = "create_ask_error"                // 매도 주문 생성 실패
+	ErrCreateBidError               ErrorCode = "create_bid_error"                // 매수 주문 생성 실패
+	ErrInsufficientFundsAsk         ErrorCode = "insufficient_funds_ask"          // 매도 가능 잔고 부족
+	ErrInsufficientFundsBid         ErrorCode = "insufficient_funds_bid"          // 매수 가능 잔고 부족
+	ErrUnderMinTotalAsk             ErrorCode = "under_min_total_ask"             // 최소 매도 금액 미만
+	ErrUnderMinTotalBid             ErrorCode = "under_min_total_bid"             // 최소 매수 금액 미만
+	ErrWithdrawAddressNotRegistered ErrorCode = "withdraw_address_not_registered" // 허용되지 않은 출금 주소
+	ErrValidationError              ErrorCode = "validation_error"                // 잘못된 API 요청
+	ErrInvalidParameter             ErrorCode = "invalid_parameter"               // 잘못된 파라미터
+
+	// 401 Unauthorized
+	ErrInvalidQueryPayload ErrorCode = "invalid_query_payload" // JWT 헤더의 페이로드가 올바르지 않음
+	ErrJWTVerification     ErrorCode = "jwt_verification"      // JWT 토큰 검증 실패
+	ErrExpiredAccessKey    ErrorCode = "expired_access_key"    // 만료된 API 키
+	ErrNonceUsed           ErrorCode = "nonce_used"            // 이미 요청한 nonce 값
+	ErrNoAuthorizationIP   ErrorCode = "no_authorization_ip"   // 허용되지 않은 IP 주소
+	ErrOutOfScope          ErrorCode = "out_of_scope"          // 허용되지 않은 기능
+	ErrInvalidAccessKey    ErrorCode = "invalid_access_key"    // 잘못된 액세스 키
+
+	// 404 Not Found
+	ErrOrderNotFound ErrorCode = "order_not_found" // 주문을 찾을 수 없음
+
+	// 429 Too Many Requests
+	ErrTooManyRequests ErrorCode = "too_many_requests" // 요청 수 제한 초과
+
+	// 5xx
+	ErrServerError ErrorCode = "server_error" // 서버 내부 오류
+)
+
+// 여러 에러 이름을 묶어서 판별하기 위한 에러들입니다.
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds") // insufficient_funds_ask, insufficient_funds_bid
+	ErrUnderMinTotal     = errors.New("under min total")    // under_min_total_ask, under_min_total_bid
+	ErrAuthentication    = errors.New("authentication failed")
+)
+
+// errorCategories는 에러 이름이 속하는 분류입니다.
+var errorCategories = map[ErrorCode]error{
+	ErrInsufficientFundsAsk: ErrInsufficientFunds,
+	ErrInsufficientFundsBid: ErrInsufficientFunds,
+	ErrUnderMinTotalAsk:     ErrUnderMinTotal,
+	ErrUnderMinTotalBid:     ErrUnderMinTotal,
+	ErrInvalidQueryPayload:  ErrAuthentication,
+	ErrJWTVerification:      ErrAuthentication,
+	ErrExpiredAccessKey:     ErrAuthentication,
+	ErrNonceUsed:            ErrAuthentication,
+	ErrNoAuthorizationIP:    ErrAuthentication,
+	ErrOutOfScope:           ErrAuthentication,
+	ErrInvalidAccessKey:     ErrAuthentication,
+}
+
+// APIError는 Upbit API에서 반환하는 에러 정보를 나타냅니다.
+//
+// errors.Is로 ErrorCode 상수(예: ErrInsufficientFundsBid)나 분류 에러
+// (예: ErrAuthentication)와 비교할 수 있고, errors.As로 상태 코드와
+// 잔여 요청 수 정보를 꺼낼 수 있습니다.
+type APIError struct {
+	Name         string        `json:"name"`    // 에러 이름
+	Message      string        `json:"message"` // 에러 메시지
+	StatusCode   int           `json:"-"`       // HTTP 상태 코드
+	RemainingReq *RemainingReq `json:"-"`       // 응답의 잔여 요청 수 정보 (헤더가 없으면 nil)
+}
+
+// Error는 "에러 이름: 에러 메시지" 형식의 문자열을 반환합니다.
+// 서버가 둘 중 하나만 보낸 경우 있는 값만 반환하고, 둘 다 없으면 상태 코드로 설명합니다.
+func (e *APIError) Error() string {
+	switch {
+	case e.Name != "" && e.Message != "":
+		return e.Name + ": " + e.Message
+	case e.Name != "":
+		return e.Name
+	case e.Message != "":
+		return e.Message
+	default:
+		return fmt.Sprintf("upbit api error (status %d)", e.StatusCode)
+	}
+}
+
+// Code는 에러 이름을 ErrorCode로 반환합니다.
+func (e *APIError) Code() ErrorCode {
+	return ErrorCode(e.Name)
+}
+
+// Is는 target이 이 에러의 ErrorCode 또는 분류 에러와 같은지 확인합니다.
+func (e *APIError) Is(target error) bool {
+	if code, ok := target.(ErrorCode); ok {
+		return e.Code() == code
+	}
+	return target != nil && errorCategories[e.Code()] == target
+}
+
+// Temporary는 잠시 후 다시 시도하면 성공할 수 있는 에러인지 확인합니다.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// ErrorResponse는 API 에러 응답을 나타냅니다.
+type ErrorResponse struct {
+	Error APIError `json:"error"` // API 에러 정보
+}
+
+// newStatusError는 에러 본문을 해석할 수 없는 응답에 대한 APIError를 생성합니다.
+// 429 응답은 본문이 JSON이 아닌 경우가 많으므로 상태 코드로 에러 이름을 정합니다.
+func newStatusError(statusCode int, body []byte) *APIError {
+	name := ""
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		name = string(ErrTooManyRequests)
+	case statusCode >= http.StatusInternalServerError:
+		name = string(ErrServerError)
+	}
+
+	message := strings.TrimSpace(string(body))
+	if message == "" || len(message) > 200 {
+		message = http.StatusText(statusCode)
+	}
+
+	return &APIError{
+		Name:       name,
+		Message:    message,
+		StatusCode: statusCode,
+	}
+}
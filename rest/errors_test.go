@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{name: "insufficient_funds_bid", target: ErrInsufficientFundsBid, want: true},
+		{name: "insufficient_funds_bid", target: ErrInsufficientFundsAsk, want: false},
+		{name: "insufficient_funds_bid", target: ErrInsufficientFunds, want: true},
+		{name: "insufficient_funds_ask", target: ErrInsufficientFunds, want: true},
+		{name: "under_min_total_bid", target: ErrUnderMinTotal, want: true},
+		{name: "under_min_total_ask", target: ErrUnderMinTotal, want: true},
+		{name: "under_min_total_ask", target: ErrInsufficientFunds, want: false},
+		{name: "jwt_verification", target: ErrAuthentication, want: true},
+		{name: "invalid_query_payload", target: ErrAuthentication, want: true},
+		{name: "expired_access_key", target: ErrAuthentication, want: true},
+		{name: "nonce_used", target: ErrAuthentication, want: true},
+		{name: "no_authorization_ip", target: ErrAuthentication, want: true},
+		{name: "out_of_scope", target: ErrAuthentication, want: true},
+		{name: "invalid_access_key", target: ErrAuthentication, want: true},
+		{name: "validation_error", target: ErrAuthentication, want: false},
+		{name: "", target: ErrAuthentication, want: false},
+		{name: "server_error", target: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		// 다른 에러로 감싸도 errors.Is로 판별할 수 있습니다.
+		err := fmt.Errorf("wrapped: %w", &APIError{Name: tt.name, Message: "message"})
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%q, %v) = %v, want %v", tt.name, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorTemporary(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{statusCode: http.StatusBadRequest, want: false},
+		{statusCode: http.StatusUnauthorized, want: false},
+		{statusCode: http.StatusNotFound, want: false},
+		{statusCode: http.StatusTooManyRequests, want: true},
+		{statusCode: http.StatusInternalServerError, want: true},
+		{statusCode: http.StatusBadGateway, want: true},
+		{statusCode: http.StatusGatewayTimeout, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := (&APIError{StatusCode: tt.statusCode}).Temporary(); got != tt.want {
+			t.Errorf("Temporary() with status %d = %v, want %v", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	tests := []struct {
+		err  APIError
+		want string
+	}{
+		{err: APIError{Name: "order_not_found", Message: "주문을 찾지 못했습니다."}, want: "order_not_found: 주문을 찾지 못했습니다."},
+		{err: APIError{Name: "order_not_found"}, want: "order_not_found"},
+		{err: APIError{Message: "주문을 찾지 못했습니다."}, want: "주문을 찾지 못했습니다."},
+		{err: APIError{StatusCode: http.StatusBadGateway}, want: "upbit api error (status 502)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestNewStatusError(t *testing.T) {
+	longBody := "<html>" + strings.Repeat("x", 300) + "</html>"
+
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		wantName    string
+		wantMessage string
+	}{
+		{name: "429 HTML", statusCode: http.StatusTooManyRequests, body: "<html>Too Many Requests</html>", wantName: "too_many_requests", wantMessage: "<html>Too Many Requests</html>"},
+		{name: "502 긴 HTML", statusCode: http.StatusBadGateway, body: longBody, wantName: "server_error", wantMessage: "Bad Gateway"},
+		{name: "503 빈 본문", statusCode: http.StatusServiceUnavailable, body: " \n", wantName: "server_error", wantMessage: "Service Unavailable"},
+		{name: "404 텍스트", statusCode: http.StatusNotFound, body: "not found", wantName: "", wantMessage: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newStatusError(tt.statusCode, []byte(tt.body))
+			if err.Name != tt.wantName || err.Message != tt.wantMessage || err.StatusCode != tt.statusCode {
+				t.Errorf("newStatusError = %+v, want name %q, message %q", err, tt.wantName, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestClientErrorResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       error
+		temporary  bool
+	}{
+		{name: "429 HTML", statusCode: http.StatusTooManyRequests, body: "<html><body>Too Many Requests</body></html>", want: ErrTooManyRequests, temporary: true},
+		{name: "502 HTML", statusCode: http.StatusBadGateway, body: "<html><body>502 Bad Gateway</body></html>", want: ErrServerError, temporary: true},
+		{name: "JSON 에러", statusCode: http.StatusBadRequest, body: `{"error":{"name":"insufficient_funds_bid","message":"매수가능금액이 부족합니다."}}`, want: ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(RemainingReqHeader, "group=order; min=1799; sec=0")
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			c := newTestClient(srv)
+
+			_, err := c.Post("/orders", map[string]string{"market": "KRW-BTC"})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %T, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode || apiErr.Temporary() != tt.temporary {
+				t.Errorf("StatusCode = %d, Temporary = %v, want %d, %v", apiErr.StatusCode, apiErr.Temporary(), tt.statusCode, tt.temporary)
+			}
+			if rr := apiErr.RemainingReq; rr == nil || rr.Group != GroupOrder || rr.Sec != 0 {
+				t.Errorf("RemainingReq = %+v, want order group with sec=0", rr)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Accounts는 Upbit 거래소의 계좌 정보를 나타내는 구조체입니다.
@@ -12,13 +15,13 @@ type Account struct {
 	Currency string `json:"currency,omitempty"`
 
 	// Balance는 해당 화폐의 주문 가능한 잔고 수량입니다.
-	Balance string `json:"balance,omitempty"`
+	Balance decimal.Decimal `json:"balance"`
 
 	// Locked는 해당 화폐의 주문이 걸려있는 잔고 수량입니다.
-	Locked string `json:"locked,omitempty"`
+	Locked decimal.Decimal `json:"locked"`
 
 	// AvgBuyPrice는 해당 화폐의 매수 평균가입니다.
-	AvgBuyPrice string `json:"avg_buy_price,omitempty"`
+	AvgBuyPrice decimal.Decimal `json:"avg_buy_price"`
 
 	// AvgBuyPriceModified는 매수 평균가 수정 여부를 나타냅니다.
 	// 수정되었다면 true, 아니면 false입니다.
@@ -33,7 +36,12 @@ type Account struct {
 // 이 메서드는 인증이 필요한 API를 호출하며, 전체 계좌 잔고와 관련 정보를 반환합니다.
 // 에러가 발생한 경우 error를 반환합니다.
 func (e *Exchange) GetAccounts() ([]Account, error) {
-	resp, err := e.Client.Get("/accounts", nil)
+	return e.GetAccountsCtx(context.Background())
+}
+
+// GetAccountsCtx는 GetAccounts와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetAccountsCtx(ctx context.Context) ([]Account, error) {
+	resp, err := e.Client.GetCtx(ctx, "/accounts", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// APIKey는 계정에 발급된 API 키 정보를 나타냅니다.
+type APIKey struct {
+	AccessKey string    `json:"access_key"` // 액세스 키
+	ExpireAt  time.Time `json:"expire_at"`  // 만료 시각
+}
+
+// expireAtMinuteLayout은 초 단위가 없는 만료 시각 형식입니다 (예: 2021-03-09T12:24+09:00).
+const expireAtMinuteLayout = "2006-01-02T15:04Z07:00"
+
+// apiKeyAlias는 APIKey의 JSON 메서드가 재귀 호출되지 않도록 하는 별칭입니다.
+type apiKeyAlias APIKey
+
+// UnmarshalJSON은 만료 시각을 RFC 3339 형식으로 해석하고, 실패하면 초 단위가 없는 형식으로 해석합니다.
+// Upbit API 문서의 응답 예시는 초 단위를 생략한 형식을 사용합니다.
+func (k *APIKey) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		*apiKeyAlias
+		ExpireAt string `json:"expire_at"`
+	}{apiKeyAlias: (*apiKeyAlias)(k)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.ExpireAt == "" {
+		k.ExpireAt = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, aux.ExpireAt)
+	if err != nil {
+		if t, err = time.Parse(expireAtMinuteLayout, aux.ExpireAt); err != nil {
+			return fmt.Errorf("invalid expire_at %q: %w", aux.ExpireAt, err)
+		}
+	}
+	k.ExpireAt = t
+	return nil
+}
+
+// ExpiresIn은 now 기준으로 키가 만료될 때까지 남은 시간을 반환합니다.
+// 이미 만료되었으면 0 이하의 값을 반환합니다.
+func (k APIKey) ExpiresIn(now time.Time) time.Duration {
+	return k.ExpireAt.Sub(now)
+}
+
+// KeyExpiryFunc는 만료가 가까운 API 키를 발견했거나 만료 확인에 실패했을 때 호출되는 함수입니다.
+// remaining은 만료까지 남은 시간이며, 이미 만료되었으면 0 이하입니다.
+// 확인에 실패하면 err에 원인이 전달되며, 이때 key에는 확인하려던 액세스 키만 채워지고 remaining은 0입니다.
+type KeyExpiryFunc func(key APIKey, remaining time.Duration, err error)
+
+// GetAPIKeys는 계정에 발급된 API 키 목록과 만료 시각을 조회합니다.
+func (e *Exchange) GetAPIKeys() ([]APIKey, error) {
+	return e.GetAPIKeysCtx(context.Background())
+}
+
+// GetAPIKeysCtx는 GetAPIKeys와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetAPIKeysCtx(ctx context.Context) ([]APIKey, error) {
+	resp, err := e.Client.GetCtx(ctx, "/api_keys", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(resp, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// CheckKeyExpiry는 API 키 목록을 조회하여 within 안에 만료되는 키가 있으면 warn을 호출합니다.
+// accessKey를 지정하면 해당 키만 확인하며, 목록에 없으면 에러를 반환합니다.
+// 빈 문자열이면 계정의 모든 키를 확인합니다. 확인한 키 목록을 반환합니다.
+// 확인에 실패하면 에러를 반환하기 전에 warn에도 에러를 전달합니다.
+//
+//	_, err := client.GetExchange().CheckKeyExpiry(accessKey, 7*24*time.Hour, func(key exchange.APIKey, remaining time.Duration, err error) {
+//		if err != nil {
+//			log.Printf("API 키 만료 확인 실패: %v", err)
+//			return
+//		}
+//		log.Printf("API 키 %s가 %v 후 만료됩니다", key.AccessKey, remaining)
+//	})
+func (e *Exchange) CheckKeyExpiry(accessKey string, within time.Duration, warn KeyExpiryFunc) ([]APIKey, error) {
+	return e.CheckKeyExpiryCtx(context.Background(), accessKey, within, warn)
+}
+
+// CheckKeyExpiryCtx는 CheckKeyExpiry와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CheckKeyExpiryCtx(ctx context.Context, accessKey string, within time.Duration, warn KeyExpiryFunc) ([]APIKey, error) {
+	keys, err := e.checkKeyExpiry(ctx, accessKey, within, warn)
+	if err != nil && warn != nil {
+		warn(APIKey{AccessKey: accessKey}, 0, err)
+	}
+	return keys, err
+}
+
+// checkKeyExpiry는 CheckKeyExpiryCtx의 구현으로, 실패를 warn에 전달하지 않습니다.
+func (e *Exchange) checkKeyExpiry(ctx context.Context, accessKey string, within time.Duration, warn KeyExpiryFunc) ([]APIKey, error) {
+	keys, err := e.GetAPIKeysCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if accessKey != "" {
+		var found []APIKey
+		for _, key := range keys {
+			if key.AccessKey == accessKey {
+				found = append(found, key)
+			}
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("access key %s not found in API key list", accessKey)
+		}
+		keys = found
+	}
+
+	now := time.Now()
+	for _, key := range keys {
+		if remaining := key.ExpiresIn(now); remaining <= within && warn != nil {
+			warn(key, remaining, nil)
+		}
+	}
+
+	return keys, nil
+}
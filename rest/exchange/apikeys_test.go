@@ -0,0 +1,43 @@
+package exchange
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyUnmarshalJSON(t *testing.T) {
+	kst := time.FixedZone("", 9*60*60)
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "RFC 3339", input: `"2021-03-09T12:24:30+09:00"`, want: time.Date(2021, 3, 9, 12, 24, 30, 0, kst)},
+		{name: "소수 초", input: `"2021-03-09T03:24:30.5Z"`, want: time.Date(2021, 3, 9, 3, 24, 30, 5e8, time.UTC)},
+		{name: "초 단위 없음", input: `"2021-03-09T12:24+09:00"`, want: time.Date(2021, 3, 9, 12, 24, 0, 0, kst)},
+		{name: "초 단위 없는 UTC", input: `"2021-03-09T03:24Z"`, want: time.Date(2021, 3, 9, 3, 24, 0, 0, time.UTC)},
+		{name: "빈 값", input: `""`, want: time.Time{}},
+		{name: "잘못된 형식", input: `"2021-03-09"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var key APIKey
+			err := json.Unmarshal([]byte(`{"access_key":"ak","expire_at":`+tt.input+`}`), &key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal succeeded: %+v", key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if key.AccessKey != "ak" || !key.ExpireAt.Equal(tt.want) {
+				t.Errorf("APIKey = %+v, want ExpireAt %v", key, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Package exchange는 Upbit 거래소의 입출금 관련 API를 제공합니다.
@@ -26,17 +30,17 @@ const (
 
 // DepositInfo는 입금 정보를 나타냅니다.
 type DepositInfo struct {
-	Type            string    `json:"type"`             // 입금 종류
-	UUID            string    `json:"uuid"`             // 입금 고유 식별자
-	Currency        string    `json:"currency"`         // 화폐를 의미하는 영문 대문자 코드
-	NetType         string    `json:"net_type"`         // 입금 네트워크 종류
-	TxID            string    `json:"txid"`             // 블록체인 트랜잭션 ID
-	State           string    `json:"state"`            // 입금 상태
-	CreatedAt       time.Time `json:"created_at"`       // 입금 요청 시각
-	DoneAt          time.Time `json:"done_at"`          // 입금 완료 시각
-	Amount          string    `json:"amount"`           // 입금 수량
-	Fee             string    `json:"fee"`              // 입금 수수료
-	TransactionType string    `json:"transaction_type"` // 입금 유형
+	Type            string          `json:"type"`             // 입금 종류
+	UUID            string          `json:"uuid"`             // 입금 고유 식별자
+	Currency        string          `json:"currency"`         // 화폐를 의미하는 영문 대문자 코드
+	NetType         string          `json:"net_type"`         // 입금 네트워크 종류
+	TxID            string          `json:"txid"`             // 블록체인 트랜잭션 ID
+	State           string          `json:"state"`            // 입금 상태
+	CreatedAt       time.Time       `json:"created_at"`       // 입금 요청 시각
+	DoneAt          time.Time       `json:"done_at"`          // 입금 완료 시각
+	Amount          decimal.Decimal `json:"amount"`           // 입금 수량
+	Fee             decimal.Decimal `json:"fee"`              // 입금 수수료
+	TransactionType string          `json:"transaction_type"` // 입금 유형
 }
 
 // DepositAddress는 입금 주소 정보를 나타내는 구조체입니다.
@@ -61,13 +65,13 @@ type GenerateCoinAddressResponse struct {
 
 // DepositCoinChance는 암호화폐 입금 관련 정보를 나타내는 구조체입니다.
 type DepositCoinChance struct {
-	Currency                    string `json:"currency"`                      // 화폐를 의미하는 영문 대문자 코드
-	NetType                     string `json:"net_type"`                      // 입금 네트워크 종류
-	IsDepositPossible           bool   `json:"is_deposit_possible"`           // 입금 가능 여부
-	DepositImpossibleReason     string `json:"deposit_impossible_reason"`     // 입금이 불가능한 경우 그 사유
-	MinimumDepositAmount        string `json:"minimum_deposit_amount"`        // 최소 입금 가능 수량
-	MinimumDepositConfirmations int    `json:"minimum_deposit_confirmations"` // 입금 확인에 필요한 최소 블록 확인 수
-	DecimalPrecision            int    `json:"decimal_precision"`             // 입금 수량의 소수점 자릿수
+	Currency                    string          `json:"currency"`                      // 화폐를 의미하는 영문 대문자 코드
+	NetType                     string          `json:"net_type"`                      // 입금 네트워크 종류
+	IsDepositPossible           bool            `json:"is_deposit_possible"`           // 입금 가능 여부
+	DepositImpossibleReason     string          `json:"deposit_impossible_reason"`     // 입금이 불가능한 경우 그 사유
+	MinimumDepositAmount        decimal.Decimal `json:"minimum_deposit_amount"`        // 최소 입금 가능 수량
+	MinimumDepositConfirmations int             `json:"minimum_deposit_confirmations"` // 입금 확인에 필요한 최소 블록 확인 수
+	DecimalPrecision            int             `json:"decimal_precision"`             // 입금 수량의 소수점 자릿수
 }
 
 // DepositKRWParams는 원화 입금 요청에 필요한 파라미터입니다.
@@ -98,6 +102,11 @@ type DepositListParams struct {
 // 생성된 주소 정보와 함께 성공 여부를 반환합니다.
 // 파라미터로 화폐 코드와 네트워크 유형이 필요합니다.
 func (e *Exchange) GenerateCoinAddress(params *GenerateCoinAddressParams) (*GenerateCoinAddressResponse, error) {
+	return e.GenerateCoinAddressCtx(context.Background(), params)
+}
+
+// GenerateCoinAddressCtx는 GenerateCoinAddress와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GenerateCoinAddressCtx(ctx context.Context, params *GenerateCoinAddressParams) (*GenerateCoinAddressResponse, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -109,7 +118,11 @@ func (e *Exchange) GenerateCoinAddress(params *GenerateCoinAddressParams) (*Gene
 		return nil, errors.New("net_type is required")
 	}
 
-	resp, err := e.Client.Post("/deposits/generate_coin_address", params)
+	if e.skipDryRun("GenerateCoinAddress", params) {
+		return &GenerateCoinAddressResponse{}, nil
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/deposits/generate_coin_address", params)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +138,12 @@ func (e *Exchange) GenerateCoinAddress(params *GenerateCoinAddressParams) (*Gene
 // GetCoinAddresses는 사용자의 전체 입금 주소 목록을 조회합니다.
 // 등록된 모든 화폐의 입금 주소 정보를 반환합니다.
 func (e *Exchange) GetCoinAddresses() ([]DepositAddress, error) {
-	resp, err := e.Client.Get("/deposits/coin_addresses", nil)
+	return e.GetCoinAddressesCtx(context.Background())
+}
+
+// GetCoinAddressesCtx는 GetCoinAddresses와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetCoinAddressesCtx(ctx context.Context) ([]DepositAddress, error) {
+	resp, err := e.Client.GetCtx(ctx, "/deposits/coin_addresses", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -142,18 +160,23 @@ func (e *Exchange) GetCoinAddresses() ([]DepositAddress, error) {
 // currency는 화폐 코드, netType은 네트워크 유형을 지정합니다.
 // 해당 화폐의 입금 주소가 없는 경우 오류를 반환합니다.
 func (e *Exchange) GetCoinAddress(currency string, netType string) (*DepositAddress, error) {
+	return e.GetCoinAddressCtx(context.Background(), currency, netType)
+}
+
+// GetCoinAddressCtx는 GetCoinAddress와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetCoinAddressCtx(ctx context.Context, currency string, netType string) (*DepositAddress, error) {
 	if currency == "" {
 		return nil, errors.New("currency is required")
 	}
 
-	params := map[string]string{
-		"currency": currency,
+	params := url.Values{
+		"currency": {currency},
 	}
 	if netType != "" {
-		params["net_type"] = netType
+		params.Set("net_type", netType)
 	}
 
-	resp, err := e.Client.Get("/deposits/coin_address", params)
+	resp, err := e.Client.GetCtx(ctx, "/deposits/coin_address", params)
 	if err != nil {
 		return nil, err
 	}
@@ -169,15 +192,20 @@ func (e *Exchange) GetCoinAddress(currency string, netType string) (*DepositAddr
 // GetDepositCoinChance는 암호화폐 입금 관련 정보를 조회합니다.
 // currency로 지정한 화폐의 입금 가능 여부와 최소 입금액 등의 정보를 반환합니다.
 func (e *Exchange) GetDepositCoinChance(currency string) (*DepositCoinChance, error) {
+	return e.GetDepositCoinChanceCtx(context.Background(), currency)
+}
+
+// GetDepositCoinChanceCtx는 GetDepositCoinChance와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetDepositCoinChanceCtx(ctx context.Context, currency string) (*DepositCoinChance, error) {
 	if currency == "" {
 		return nil, errors.New("currency is required")
 	}
 
-	params := map[string]string{
-		"currency": currency,
+	params := url.Values{
+		"currency": {currency},
 	}
 
-	resp, err := e.Client.Get("/deposits/chance/coin", params)
+	resp, err := e.Client.GetCtx(ctx, "/deposits/chance/coin", params)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +221,11 @@ func (e *Exchange) GetDepositCoinChance(currency string) (*DepositCoinChance, er
 // DepositKRW는 원화 입금을 요청합니다.
 // 입금액과 2차 인증 방식을 지정해야 하며, 입금 성공 시 입금 정보를 반환합니다.
 func (e *Exchange) DepositKRW(params *DepositKRWParams) (*DepositInfo, error) {
+	return e.DepositKRWCtx(context.Background(), params)
+}
+
+// DepositKRWCtx는 DepositKRW와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) DepositKRWCtx(ctx context.Context, params *DepositKRWParams) (*DepositInfo, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -204,7 +237,11 @@ func (e *Exchange) DepositKRW(params *DepositKRWParams) (*DepositInfo, error) {
 		return nil, errors.New("two_factor_type is required")
 	}
 
-	resp, err := e.Client.Post("/deposits/krw", params)
+	if e.skipDryRun("DepositKRW", params) {
+		return &DepositInfo{}, nil
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/deposits/krw", params)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +257,11 @@ func (e *Exchange) DepositKRW(params *DepositKRWParams) (*DepositInfo, error) {
 // GetDeposit는 특정 입금 내역을 조회합니다.
 // UUID, TxID, Currency 중 최소 하나의 식별자가 필요합니다.
 func (e *Exchange) GetDeposit(params *GetDepositParams) (*DepositInfo, error) {
+	return e.GetDepositCtx(context.Background(), params)
+}
+
+// GetDepositCtx는 GetDeposit와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetDepositCtx(ctx context.Context, params *GetDepositParams) (*DepositInfo, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -229,18 +271,18 @@ func (e *Exchange) GetDeposit(params *GetDepositParams) (*DepositInfo, error) {
 		return nil, errors.New("either uuid, txid, or currency must be provided")
 	}
 
-	queryParams := make(map[string]string)
+	queryParams := make(url.Values)
 	if params.UUID != "" {
-		queryParams["uuid"] = params.UUID
+		queryParams.Set("uuid", params.UUID)
 	}
 	if params.TxID != "" {
-		queryParams["txid"] = params.TxID
+		queryParams.Set("txid", params.TxID)
 	}
 	if params.Currency != "" {
-		queryParams["currency"] = params.Currency
+		queryParams.Set("currency", params.Currency)
 	}
 
-	resp, err := e.Client.Get("/deposit", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/deposit", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -256,44 +298,41 @@ func (e *Exchange) GetDeposit(params *GetDepositParams) (*DepositInfo, error) {
 // GetDeposits는 입금 목록을 조회합니다.
 // 조회 조건을 params로 지정할 수 있으며, 미지정 시 기본값이 적용됩니다.
 func (e *Exchange) GetDeposits(params *DepositListParams) ([]DepositInfo, error) {
-	queryParams := make(map[string]string)
+	return e.GetDepositsCtx(context.Background(), params)
+}
+
+// GetDepositsCtx는 GetDeposits와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetDepositsCtx(ctx context.Context, params *DepositListParams) ([]DepositInfo, error) {
+	queryParams := make(url.Values)
 
 	if params != nil {
 		if params.Currency != "" {
-			queryParams["currency"] = params.Currency
+			queryParams.Set("currency", params.Currency)
 		}
 		if params.State != "" {
-			queryParams["state"] = params.State
+			queryParams.Set("state", params.State)
 		}
-		if len(params.UUIDs) > 0 {
-			uuidsBytes, err := json.Marshal(params.UUIDs)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["uuids"] = string(uuidsBytes)
+		for _, uuid := range params.UUIDs {
+			queryParams.Add("uuids[]", uuid)
 		}
-		if len(params.TxIDs) > 0 {
-			txidsBytes, err := json.Marshal(params.TxIDs)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["txids"] = string(txidsBytes)
+		for _, txid := range params.TxIDs {
+			queryParams.Add("txids[]", txid)
 		}
 		if params.Limit > 0 {
 			if params.Limit > 100 {
 				params.Limit = 100
 			}
-			queryParams["limit"] = strconv.Itoa(params.Limit)
+			queryParams.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.Page > 0 {
-			queryParams["page"] = strconv.Itoa(params.Page)
+			queryParams.Set("page", strconv.Itoa(params.Page))
 		}
 		if params.OrderBy != "" {
-			queryParams["order_by"] = params.OrderBy
+			queryParams.Set("order_by", params.OrderBy)
 		}
 	}
 
-	resp, err := e.Client.Get("/deposits", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/deposits", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"fmt"
+	"io"
+)
+
+// DryRunLogFunc는 모의 실행 모드에서 거래소에 보내지 않은 요청을 기록하는 함수입니다.
+// action은 호출된 메서드 이름이며, request는 메서드에 전달된 파라미터입니다.
+type DryRunLogFunc func(action string, request interface{})
+
+// dryRunConfig는 모의 실행 모드 설정입니다.
+type dryRunConfig struct {
+	logf DryRunLogFunc // 요청 기록 함수
+}
+
+// SetDryRun은 모의 실행 모드를 켜거나 끕니다. 실행 중에도 안전하게 바꿀 수 있습니다.
+//
+// 모의 실행 모드에서는 잔고나 주문을 바꾸는 요청을 거래소에 보내지 않고 logf로 기록합니다.
+// 조회 API는 그대로 동작하므로 실제 데이터를 보면서 새 전략을 시험할 수 있습니다.
+//
+//   - CreateOrder는 주문 생성 테스트(TestOrder)로 검증만 하고 그 응답을 반환합니다.
+//   - CancelOrder, CancelOrdersByIDs, CancelOpenOrders, CancelAndNewOrder는 요청을 보내지 않고
+//     요청한 UUID와 식별자만 채운 응답을 반환합니다. CancelAndNewOrder의 새 주문 UUID는 조회할 수 없는 임시 값입니다.
+//   - WithdrawKRW, WithdrawCoin은 요청을 보내지 않고 UUID가 비어 있는 응답을 반환합니다.
+//   - DepositKRW, GenerateCoinAddress는 요청을 보내지 않고 빈 응답을 반환합니다.
+//
+// 파라미터 검증은 모의 실행 모드에서도 그대로 수행됩니다. logf가 nil이면 기록하지 않습니다.
+func (e *Exchange) SetDryRun(enabled bool, logf DryRunLogFunc) {
+	if !enabled {
+		e.dryRun.Store(nil)
+		return
+	}
+	e.dryRun.Store(&dryRunConfig{logf: logf})
+}
+
+// IsDryRun은 모의 실행 모드가 켜져 있는지 확인합니다.
+func (e *Exchange) IsDryRun() bool {
+	return e.dryRun.Load() != nil
+}
+
+// skipDryRun은 모의 실행 모드이면 요청을 기록하고 true를 반환합니다.
+func (e *Exchange) skipDryRun(action string, request interface{}) bool {
+	cfg := e.dryRun.Load()
+	if cfg == nil {
+		return false
+	}
+	if cfg.logf != nil {
+		cfg.logf(action, request)
+	}
+	return true
+}
+
+// DryRunWriter는 보내지 않은 요청을 w에 한 줄씩 기록하는 DryRunLogFunc를 반환합니다.
+func DryRunWriter(w io.Writer) DryRunLogFunc {
+	return func(action string, request interface{}) {
+		fmt.Fprintf(w, "모의 실행 (요청을 보내지 않음): %s %+v\n", action, request)
+	}
+}
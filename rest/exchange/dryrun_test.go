@@ -0,0 +1,180 @@
+package exchange_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+)
+
+func TestDryRunCreateOrder(t *testing.T) {
+	srv, ex := newTestExchange(t)
+
+	var log bytes.Buffer
+	ex.SetDryRun(true, exchange.DryRunWriter(&log))
+
+	var meta rest.Response
+	ctx := rest.WithResponseCapture(context.Background(), &meta)
+	order, err := ex.CreateOrderCtx(ctx, &exchange.CreateOrderRequest{
+		Market:     "KRW-BTC",
+		Side:       exchange.OrderSideBid,
+		OrderType:  exchange.OrderTypeLimit,
+		Price:      "40000000",
+		Volume:     "0.001",
+		Identifier: "dry",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	if order.UUID == "" {
+		t.Error("CreateOrder returned no UUID")
+	}
+
+	// 주문 생성 테스트로만 보내며, 주문은 생성되지 않습니다.
+	reqs := srv.Requests()
+	if len(reqs) != 1 || reqs[0].Method != "POST" || reqs[0].Path != "/orders/test" {
+		t.Fatalf("requests = %+v, want POST /orders/test", reqs)
+	}
+	if reqs[0].Params.Get("identifier") != "dry" {
+		t.Errorf("identifier = %q, want dry", reqs[0].Params.Get("identifier"))
+	}
+	if open, err := ex.GetOpenOrders(&exchange.OpenOrderParams{Market: "KRW-BTC"}); err != nil || len(open) != 0 {
+		t.Errorf("GetOpenOrders = %d orders, %v, want none", len(open), err)
+	}
+
+	if rr := meta.RemainingReq; rr == nil || rr.Group != rest.GroupOrderTest || rr.Sec != 7 {
+		t.Errorf("RemainingReq = %+v, want order-test group with sec=7", rr)
+	}
+	if got := log.String(); !strings.Contains(got, "CreateOrder") || !strings.Contains(got, "KRW-BTC") {
+		t.Errorf("DryRunWriter output = %q", got)
+	}
+
+	// 모의 실행 모드를 끄면 주문을 생성합니다.
+	ex.SetDryRun(false, nil)
+	if _, err := ex.CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypeLimit, Price: "40000000", Volume: "0.001"}); err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	if reqs := srv.Requests(); reqs[len(reqs)-1].Path != "/orders" {
+		t.Errorf("request path = %s, want /orders", reqs[len(reqs)-1].Path)
+	}
+}
+
+func TestDryRunSendsNothing(t *testing.T) {
+	tests := []struct {
+		action string
+		call   func(ex *exchange.Exchange) (interface{}, error)
+		check  func(t *testing.T, result interface{})
+	}{
+		{
+			action: "CancelOrder",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.CancelOrder(&exchange.CancelOrderParams{Identifier: "my-order"})
+			},
+			check: func(t *testing.T, result interface{}) {
+				if o := result.(*exchange.Order); o.Identifier != "my-order" || o.State != exchange.OrderStateCancel {
+					t.Errorf("CancelOrder = %+v, want identifier my-order and cancel state", o)
+				}
+			},
+		},
+		{
+			action: "CancelOrdersByIDs",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.CancelOrdersByIDs(&exchange.CancelOrdersByIDsParams{UUIDs: []string{"a", "b"}})
+			},
+			check: func(t *testing.T, result interface{}) {
+				if r := result.(*exchange.BatchCancelResult); r.Success.Count != 2 || len(r.Success.Orders) != 2 {
+					t.Errorf("CancelOrdersByIDs = %+v, want 2 orders", r.Success)
+				}
+			},
+		},
+		{
+			action: "CancelOpenOrders",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.CancelOpenOrders(&exchange.CancelOpenOrdersParams{CancelSide: exchange.CancelSideAll})
+			},
+		},
+		{
+			action: "WithdrawKRW",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.WithdrawKRW(&exchange.WithdrawKRWParams{Amount: "10000", TwoFactorType: exchange.TwoFactorTypeKakao})
+			},
+			check: func(t *testing.T, result interface{}) {
+				if r := result.(*exchange.WithdrawKRWResponse); r.UUID != "" || r.Amount.String() != "10000" {
+					t.Errorf("WithdrawKRW = %+v, want empty UUID and amount 10000", r)
+				}
+			},
+		},
+		{
+			action: "WithdrawCoin",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.WithdrawCoin(&exchange.WithdrawCoinParams{Currency: "BTC", NetType: "BTC", Amount: "0.01", Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"})
+			},
+			check: func(t *testing.T, result interface{}) {
+				if r := result.(*exchange.WithdrawCoinResponse); r.UUID != "" || r.Currency != "BTC" {
+					t.Errorf("WithdrawCoin = %+v, want empty UUID and BTC", r)
+				}
+			},
+		},
+		{
+			action: "DepositKRW",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.DepositKRW(&exchange.DepositKRWParams{Amount: "10000", TwoFactorType: exchange.TwoFactorTypeKakao})
+			},
+		},
+		{
+			action: "GenerateCoinAddress",
+			call: func(ex *exchange.Exchange) (interface{}, error) {
+				return ex.GenerateCoinAddress(&exchange.GenerateCoinAddressParams{Currency: "BTC", NetType: "BTC"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			srv, ex := newTestExchange(t)
+
+			var actions []string
+			var requests []interface{}
+			ex.SetDryRun(true, func(action string, request interface{}) {
+				actions = append(actions, action)
+				requests = append(requests, request)
+			})
+
+			result, err := tt.call(ex)
+			if err != nil {
+				t.Fatalf("%s error: %v", tt.action, err)
+			}
+			if reqs := srv.Requests(); len(reqs) != 0 {
+				t.Errorf("sent %d requests, want none", len(reqs))
+			}
+			if len(actions) != 1 || actions[0] != tt.action || requests[0] == nil {
+				t.Errorf("dry-run log = %v, want [%s] with its request", actions, tt.action)
+			}
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestDryRunValidatesParams(t *testing.T) {
+	srv, ex := newTestExchange(t)
+
+	var actions []string
+	ex.SetDryRun(true, func(action string, request interface{}) {
+		actions = append(actions, action)
+	})
+
+	if _, err := ex.CancelOrder(&exchange.CancelOrderParams{}); err == nil {
+		t.Error("CancelOrder without uuid or identifier succeeded")
+	}
+	if _, err := ex.WithdrawKRW(&exchange.WithdrawKRWParams{Amount: "10000", TwoFactorType: "sms"}); err == nil {
+		t.Error("WithdrawKRW with an invalid two_factor_type succeeded")
+	}
+	if len(actions) != 0 || len(srv.Requests()) != 0 {
+		t.Errorf("invalid requests were logged %v or sent %d", actions, len(srv.Requests()))
+	}
+}
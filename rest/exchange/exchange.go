@@ -1,6 +1,8 @@
 package exchange
 
 import (
+	"sync/atomic"
+
 	"github.com/hysuki/go-upbit/rest/client"
 )
 
@@ -10,6 +12,8 @@ import (
 // REST API 요청을 처리하는 Client를 포함합니다.
 type Exchange struct {
 	Client client.RestClient // REST API 클라이언트
+
+	dryRun atomic.Pointer[dryRunConfig] // 모의 실행 모드 설정 (nil이면 꺼짐)
 }
 
 // NewExchange는 새로운 Exchange 인스턴스를 생성합니다.
@@ -1,10 +1,17 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/hysuki/go-upbit/decimal"
+	"github.com/hysuki/go-upbit/rest/client"
 )
 
 // Package exchange는 Upbit 거래소의 주문 관련 API를 제공합니다.
@@ -53,6 +60,7 @@ const (
 )
 
 // CreateOrderRequest는 주문 생성에 필요한 파라미터입니다.
+// Volume과 Price는 지정하지 않으면 요청에서 제외되도록 문자열이며, decimal.Decimal 값은 String()으로 전달합니다.
 type CreateOrderRequest struct {
 	Market      string      `json:"market,omitempty"`        // 마켓 ID
 	Side        OrderSide   `json:"side,omitempty"`          // 주문 종류
@@ -65,22 +73,73 @@ type CreateOrderRequest struct {
 
 // Order는 주문 정보를 나타냅니다.
 type Order struct {
-	UUID            string    `json:"uuid,omitempty"`             // 주문의 고유 ID
-	Side            OrderSide `json:"side,omitempty"`             // 주문 종류
-	OrderType       OrderType `json:"ord_type,omitempty"`         // 주문 방식
-	Price           string    `json:"price,omitempty"`            // 주문 가격
-	State           string    `json:"state,omitempty"`            // 주문 상태
-	Market          string    `json:"market,omitempty"`           // 마켓 ID
-	CreatedAt       string    `json:"created_at,omitempty"`       // 주문 생성 시각
-	Volume          string    `json:"volume,omitempty"`           // 주문량
-	RemainingVolume string    `json:"remaining_volume,omitempty"` // 잔여 주문량
-	ReservedFee     string    `json:"reserved_fee,omitempty"`     // 예약된 수수료
-	RemainingFee    string    `json:"remaining_fee,omitempty"`    // 잔여 수수료
-	PaidFee         string    `json:"paid_fee,omitempty"`         // 사용된 수수료
-	Locked          string    `json:"locked,omitempty"`           // 거래에 사용된 비용
-	ExecutedVolume  string    `json:"executed_volume,omitempty"`  // 체결된 양
-	TradesCount     int       `json:"trades_count,omitempty"`     // 체결 수
-	TimeInForce     string    `json:"time_in_force,omitempty"`    // 체결 조건
+	UUID            string          `json:"uuid,omitempty"`          // 주문의 고유 ID
+	Identifier      string          `json:"identifier,omitempty"`    // 조회용 사용자 지정값
+	Side            OrderSide       `json:"side,omitempty"`          // 주문 종류
+	OrderType       OrderType       `json:"ord_type,omitempty"`      // 주문 방식
+	Price           decimal.Decimal `json:"price"`                   // 주문 가격
+	State           string          `json:"state,omitempty"`         // 주문 상태
+	Market          string          `json:"market,omitempty"`        // 마켓 ID
+	CreatedAt       time.Time       `json:"created_at"`              // 주문 생성 시각
+	Volume          decimal.Decimal `json:"volume"`                  // 주문량
+	RemainingVolume decimal.Decimal `json:"remaining_volume"`        // 잔여 주문량
+	ReservedFee     decimal.Decimal `json:"reserved_fee"`            // 예약된 수수료
+	RemainingFee    decimal.Decimal `json:"remaining_fee"`           // 잔여 수수료
+	PaidFee         decimal.Decimal `json:"paid_fee"`                // 사용된 수수료
+	Locked          decimal.Decimal `json:"locked"`                  // 거래에 사용된 비용
+	ExecutedVolume  decimal.Decimal `json:"executed_volume"`         // 체결된 양
+	ExecutedFunds   decimal.Decimal `json:"executed_funds"`          // 체결된 금액
+	TradesCount     int             `json:"trades_count,omitempty"`  // 체결 수
+	TimeInForce     string          `json:"time_in_force,omitempty"` // 체결 조건
+	Trades          []OrderTrade    `json:"trades,omitempty"`        // 체결 목록 (GetOrder에서만 제공)
+}
+
+// OrderTrade는 주문의 개별 체결 내역을 나타냅니다.
+type OrderTrade struct {
+	Market    string          `json:"market"`     // 마켓 ID
+	UUID      string          `json:"uuid"`       // 체결의 고유 ID
+	Price     decimal.Decimal `json:"price"`      // 체결 가격
+	Volume    decimal.Decimal `json:"volume"`     // 체결량
+	Funds     decimal.Decimal `json:"funds"`      // 체결된 총 가격
+	Trend     string          `json:"trend"`      // 체결 시세 흐름 (up: 매수에 의한 체결, down: 매도에 의한 체결)
+	Side      OrderSide       `json:"side"`       // 체결 종류
+	CreatedAt time.Time       `json:"created_at"` // 체결 시각
+}
+
+// FilledFunds는 주문의 체결 금액을 반환합니다.
+// 체결 목록이 있으면 각 체결의 금액을 합산하고, 없으면 ExecutedFunds를 반환합니다.
+func (o Order) FilledFunds() decimal.Decimal {
+	if len(o.Trades) == 0 {
+		return o.ExecutedFunds
+	}
+	funds := decimal.Zero
+	for _, t := range o.Trades {
+		funds = funds.Add(t.Funds)
+	}
+	return funds
+}
+
+// AverageFillPrice는 체결 금액을 체결량으로 나눈 평균 체결 가격을 반환합니다.
+// 체결 목록이 있으면 체결 목록을, 없으면 ExecutedFunds와 ExecutedVolume을 사용합니다.
+// 체결되지 않은 주문은 0을 반환합니다.
+func (o Order) AverageFillPrice() decimal.Decimal {
+	volume := o.ExecutedVolume
+	if len(o.Trades) > 0 {
+		volume = decimal.Zero
+		for _, t := range o.Trades {
+			volume = volume.Add(t.Volume)
+		}
+	}
+	if volume.IsZero() {
+		return decimal.Zero
+	}
+	return o.FilledFunds().Div(volume)
+}
+
+// GetOrderParams는 개별 주문 조회에 필요한 파라미터입니다.
+type GetOrderParams struct {
+	UUID       string `json:"uuid,omitempty"`       // 조회할 주문의 UUID
+	Identifier string `json:"identifier,omitempty"` // 조회용 사용자 지정값
 }
 
 // CancelOrderParams는 주문 취소에 필요한 파라미터입니다.
@@ -89,6 +148,31 @@ type CancelOrderParams struct {
 	Identifier string `json:"identifier,omitempty"` // 조회용 사용자 지정값
 }
 
+// RemainOnly는 CancelAndNewOrderRequest.NewVolume에 지정하면 기존 주문의 잔여 수량으로
+// 새 주문을 생성하는 값입니다.
+const RemainOnly = "remain_only"
+
+// CancelAndNewOrderRequest는 주문 취소 후 재주문에 필요한 파라미터입니다.
+// PrevOrderUUID와 PrevOrderIdentifier 중 하나로 취소할 주문을 지정합니다.
+type CancelAndNewOrderRequest struct {
+	PrevOrderUUID       string      `json:"prev_order_uuid,omitempty"`       // 취소할 주문의 UUID
+	PrevOrderIdentifier string      `json:"prev_order_identifier,omitempty"` // 취소할 주문의 조회용 사용자 지정값
+	NewOrderType        OrderType   `json:"new_ord_type,omitempty"`          // 새 주문 타입
+	NewVolume           string      `json:"new_volume,omitempty"`            // 새 주문량 (RemainOnly이면 기존 주문의 잔여 수량)
+	NewPrice            string      `json:"new_price,omitempty"`             // 새 주문 가격
+	NewIdentifier       string      `json:"new_identifier,omitempty"`        // 새 주문의 조회용 사용자 지정값
+	NewTimeInForce      TimeInForce `json:"new_time_in_force,omitempty"`     // 새 주문의 체결 조건
+}
+
+// CancelAndNewOrderResponse는 주문 취소 후 재주문 결과입니다.
+// 임베드된 Order는 취소 요청된 기존 주문이며, 응답에는 새 주문의 UUID와 식별자만 포함됩니다.
+// 새 주문의 상세 정보는 Exchange.GetNewOrder로 조회합니다.
+type CancelAndNewOrderResponse struct {
+	Order
+	NewOrderUUID       string `json:"new_order_uuid"`                 // 새 주문의 UUID
+	NewOrderIdentifier string `json:"new_order_identifier,omitempty"` // 새 주문의 조회용 사용자 지정값
+}
+
 // ClosedOrderParams는 완료된 주문 조회에 필요한 파라미터입니다.
 type ClosedOrderParams struct {
 	Market    string     `json:"market,omitempty"`     // 마켓 ID
@@ -112,10 +196,55 @@ type OpenOrderParams struct {
 
 // 에러 정의
 var (
-	ErrInvalidParams = errors.New("invalid parameters")                            // 잘못된 파라미터 에러
-	ErrTooManyIDs    = errors.New("too many uuids or identifiers: maximum is 100") // ID 초과 에러
+	ErrInvalidParams    = errors.New("invalid parameters")                            // 잘못된 파라미터 에러
+	ErrTooManyIDs       = errors.New("too many uuids or identifiers: maximum is 100") // ID 초과 에러
+	ErrTooManyCancelIDs = errors.New("too many uuids or identifiers: maximum is 20")  // 일괄 취소 ID 초과 에러
 )
 
+// 일괄 취소할 주문 종류를 정의하는 상수들입니다.
+const (
+	CancelSideAll = "all" // 매수/매도 전체
+	CancelSideAsk = "ask" // 매도 주문
+	CancelSideBid = "bid" // 매수 주문
+)
+
+// CancelOrdersByIDsParams는 UUID 또는 식별자로 주문을 일괄 취소하는 데 필요한 파라미터입니다.
+// UUIDs와 Identifiers 중 하나만 최대 20개까지 지정할 수 있습니다.
+type CancelOrdersByIDsParams struct {
+	UUIDs       []string `json:"uuids,omitempty"`       // 취소할 주문 UUID 목록
+	Identifiers []string `json:"identifiers,omitempty"` // 취소할 주문 식별자 목록
+}
+
+// CancelOpenOrdersParams는 조건에 맞는 미체결 주문을 일괄 취소하는 데 필요한 파라미터입니다.
+// Pairs와 QuoteCurrencies는 함께 사용할 수 없습니다.
+type CancelOpenOrdersParams struct {
+	CancelSide      string   `json:"cancel_side,omitempty"`      // 취소할 주문 종류 (CancelSideAll, CancelSideAsk, CancelSideBid)
+	Pairs           []string `json:"pairs,omitempty"`            // 취소할 마켓 목록 (최대 20개)
+	ExcludedPairs   []string `json:"excluded_pairs,omitempty"`   // 취소하지 않을 마켓 목록 (최대 20개)
+	QuoteCurrencies []string `json:"quote_currencies,omitempty"` // 취소할 마켓의 기준 화폐 목록 (예: KRW, BTC)
+	Count           int      `json:"count,omitempty"`            // 최대 취소 개수 (최대 300)
+	OrderBy         string   `json:"order_by,omitempty"`         // 정렬 방식
+}
+
+// CancelledOrder는 일괄 취소 결과에 포함된 주문입니다.
+type CancelledOrder struct {
+	UUID       string `json:"uuid"`                 // 주문의 고유 ID
+	Market     string `json:"market"`               // 마켓 ID
+	Identifier string `json:"identifier,omitempty"` // 조회용 사용자 지정값
+}
+
+// CancelResultGroup은 일괄 취소 결과의 성공 또는 실패 목록입니다.
+type CancelResultGroup struct {
+	Count  int              `json:"count"`  // 주문 수
+	Orders []CancelledOrder `json:"orders"` // 주문 목록
+}
+
+// BatchCancelResult는 일괄 취소 결과입니다.
+type BatchCancelResult struct {
+	Success CancelResultGroup `json:"success"` // 취소 요청에 성공한 주문
+	Failed  CancelResultGroup `json:"failed"`  // 취소 요청에 실패한 주문
+}
+
 // OrderByIDParams는 주문 조회에 필요한 파라미터입니다.
 type OrderByIDParams struct {
 	Market      string   `json:"market,omitempty"`      // 마켓 ID
@@ -126,11 +255,11 @@ type OrderByIDParams struct {
 
 // OrderChance는 마켓별 주문 가능 정보를 나타냅니다.
 type OrderChance struct {
-	BidFee     string       `json:"bid_fee"`     // 매수 수수료 비율
-	AskFee     string       `json:"ask_fee"`     // 매도 수수료 비율
-	Market     OrderMarket  `json:"market"`      // 마켓 정보
-	BidAccount OrderAccount `json:"bid_account"` // 매수 계좌 정보
-	AskAccount OrderAccount `json:"ask_account"` // 매도 계좌 정보
+	BidFee     decimal.Decimal `json:"bid_fee"`     // 매수 수수료 비율
+	AskFee     decimal.Decimal `json:"ask_fee"`     // 매도 수수료 비율
+	Market     OrderMarket     `json:"market"`      // 마켓 정보
+	BidAccount OrderAccount    `json:"bid_account"` // 매수 계좌 정보
+	AskAccount OrderAccount    `json:"ask_account"` // 매도 계좌 정보
 }
 
 // OrderMarket은 마켓 정보를 나타냅니다.
@@ -143,29 +272,34 @@ type OrderMarket struct {
 	OrderSides []string        `json:"order_sides"` // 지원 주문 종류
 	Bid        OrderConstraint `json:"bid"`         // 매수 제약사항
 	Ask        OrderConstraint `json:"ask"`         // 매도 제약사항
-	MaxTotal   string          `json:"max_total"`   // 최대 매도/매수 금액
+	MaxTotal   decimal.Decimal `json:"max_total"`   // 최대 매도/매수 금액
 	State      string          `json:"state"`       // 마켓 운영 상태
 }
 
 // OrderConstraint는 마켓 거래 제약사항을 나타냅니다.
 type OrderConstraint struct {
-	Currency  string `json:"currency"`   // 화폐를 의미하는 영문 대문자 코드
-	PriceUnit string `json:"price_unit"` // 주문금액 단위
-	MinTotal  string `json:"min_total"`  // 최소 매도/매수 금액
+	Currency  string          `json:"currency"`   // 화폐를 의미하는 영문 대문자 코드
+	PriceUnit decimal.Decimal `json:"price_unit"` // 주문금액 단위
+	MinTotal  decimal.Decimal `json:"min_total"`  // 최소 매도/매수 금액
 }
 
 // OrderAccount는 매수/매도 계좌 정보를 나타냅니다.
 type OrderAccount struct {
-	Currency            string `json:"currency"`               // 화폐를 의미하는 영문 대문자 코드
-	Balance             string `json:"balance"`                // 주문가능 금액/수량
-	Locked              string `json:"locked"`                 // 주문 중 묶여있는 금액/수량
-	AvgBuyPrice         string `json:"avg_buy_price"`          // 매수평균가
-	AvgBuyPriceModified bool   `json:"avg_buy_price_modified"` // 매수평균가 수정 여부
-	UnitCurrency        string `json:"unit_currency"`          // 평단가 기준 화폐
+	Currency            string          `json:"currency"`               // 화폐를 의미하는 영문 대문자 코드
+	Balance             decimal.Decimal `json:"balance"`                // 주문가능 금액/수량
+	Locked              decimal.Decimal `json:"locked"`                 // 주문 중 묶여있는 금액/수량
+	AvgBuyPrice         decimal.Decimal `json:"avg_buy_price"`          // 매수평균가
+	AvgBuyPriceModified bool            `json:"avg_buy_price_modified"` // 매수평균가 수정 여부
+	UnitCurrency        string          `json:"unit_currency"`          // 평단가 기준 화폐
 }
 
 // GetOrdersByID는 UUID 또는 식별자로 주문 목록을 조회합니다.
 func (e *Exchange) GetOrdersByID(params *OrderByIDParams) ([]Order, error) {
+	return e.GetOrdersByIDCtx(context.Background(), params)
+}
+
+// GetOrdersByIDCtx는 GetOrdersByID와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetOrdersByIDCtx(ctx context.Context, params *OrderByIDParams) ([]Order, error) {
 	if params == nil {
 		return nil, ErrInvalidParams
 	}
@@ -185,33 +319,25 @@ func (e *Exchange) GetOrdersByID(params *OrderByIDParams) ([]Order, error) {
 		return nil, ErrTooManyIDs
 	}
 
-	queryParams := make(map[string]string)
+	queryParams := make(url.Values)
 
 	if params.Market != "" {
-		queryParams["market"] = params.Market
+		queryParams.Set("market", params.Market)
 	}
 
-	if len(params.UUIDs) > 0 {
-		uuidsBytes, err := json.Marshal(params.UUIDs)
-		if err != nil {
-			return nil, err
-		}
-		queryParams["uuids"] = string(uuidsBytes)
+	for _, uuid := range params.UUIDs {
+		queryParams.Add("uuids[]", uuid)
 	}
 
-	if len(params.Identifiers) > 0 {
-		identifiersBytes, err := json.Marshal(params.Identifiers)
-		if err != nil {
-			return nil, err
-		}
-		queryParams["identifiers"] = string(identifiersBytes)
+	for _, identifier := range params.Identifiers {
+		queryParams.Add("identifiers[]", identifier)
 	}
 
 	if params.OrderBy != "" {
-		queryParams["order_by"] = params.OrderBy
+		queryParams.Set("order_by", params.OrderBy)
 	}
 
-	resp, err := e.Client.Get("/orders/uuids", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/orders/uuids", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -224,13 +350,94 @@ func (e *Exchange) GetOrdersByID(params *OrderByIDParams) ([]Order, error) {
 	return orders, nil
 }
 
+// GetOrder는 UUID 또는 식별자로 주문 하나를 체결 목록과 함께 조회합니다.
+func (e *Exchange) GetOrder(params *GetOrderParams) (*Order, error) {
+	return e.GetOrderCtx(context.Background(), params)
+}
+
+// GetOrderCtx는 GetOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetOrderCtx(ctx context.Context, params *GetOrderParams) (*Order, error) {
+	if params == nil {
+		return nil, errors.New("params cannot be nil")
+	}
+
+	if params.UUID == "" && params.Identifier == "" {
+		return nil, errors.New("either uuid or identifier must be provided")
+	}
+
+	if params.UUID != "" && params.Identifier != "" {
+		return nil, errors.New("uuid and identifier cannot be used together")
+	}
+
+	queryParams := make(url.Values)
+	if params.UUID != "" {
+		queryParams.Set("uuid", params.UUID)
+	}
+	if params.Identifier != "" {
+		queryParams.Set("identifier", params.Identifier)
+	}
+
+	resp, err := e.Client.GetCtx(ctx, "/order", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	if err := json.Unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
 // CreateOrder는 새로운 주문을 생성합니다.
 func (e *Exchange) CreateOrder(request *CreateOrderRequest) (*Order, error) {
+	return e.CreateOrderCtx(context.Background(), request)
+}
+
+// CreateOrderCtx는 CreateOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CreateOrderCtx(ctx context.Context, request *CreateOrderRequest) (*Order, error) {
 	if request == nil {
 		return nil, ErrInvalidParams
 	}
 
-	resp, err := e.Client.Post("/orders", request)
+	// 모의 실행 모드에서는 주문 생성 테스트로 검증만 합니다.
+	if e.skipDryRun("CreateOrder", request) {
+		return e.TestOrderCtx(ctx, request)
+	}
+
+	// Identifier가 있는 주문은 서버가 중복 생성을 거부하므로 재시도해도 안전합니다.
+	if request.Identifier != "" {
+		ctx = client.WithIdempotent(ctx)
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/orders", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	if err := json.Unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// TestOrder는 주문을 실제로 생성하지 않고 Upbit 서버에서 주문 파라미터를 검증합니다.
+// 검증에 성공하면 주문 생성 응답과 같은 형식의 응답을 반환하며, 반환된 UUID로는 주문을 조회하거나 취소할 수 없습니다.
+func (e *Exchange) TestOrder(request *CreateOrderRequest) (*Order, error) {
+	return e.TestOrderCtx(context.Background(), request)
+}
+
+// TestOrderCtx는 TestOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) TestOrderCtx(ctx context.Context, request *CreateOrderRequest) (*Order, error) {
+	if request == nil {
+		return nil, ErrInvalidParams
+	}
+
+	// 주문이 생성되지 않으므로 재시도해도 안전합니다.
+	resp, err := e.Client.PostCtx(client.WithIdempotent(ctx), "/orders/test", request)
 	if err != nil {
 		return nil, err
 	}
@@ -245,6 +452,11 @@ func (e *Exchange) CreateOrder(request *CreateOrderRequest) (*Order, error) {
 
 // CancelOrder는 주문을 취소합니다.
 func (e *Exchange) CancelOrder(params *CancelOrderParams) (*Order, error) {
+	return e.CancelOrderCtx(context.Background(), params)
+}
+
+// CancelOrderCtx는 CancelOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CancelOrderCtx(ctx context.Context, params *CancelOrderParams) (*Order, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -257,15 +469,20 @@ func (e *Exchange) CancelOrder(params *CancelOrderParams) (*Order, error) {
 		return nil, errors.New("uuid and identifier cannot be used together")
 	}
 
-	queryParams := make(map[string]string)
+	queryParams := make(url.Values)
 	if params.UUID != "" {
-		queryParams["uuid"] = params.UUID
+		queryParams.Set("uuid", params.UUID)
 	}
 	if params.Identifier != "" {
-		queryParams["identifier"] = params.Identifier
+		queryParams.Set("identifier", params.Identifier)
+	}
+
+	if e.skipDryRun("CancelOrder", params) {
+		return &Order{UUID: params.UUID, Identifier: params.Identifier, State: OrderStateCancel}, nil
 	}
 
-	resp, err := e.Client.Delete("/order", queryParams)
+	// 이미 취소된 주문을 다시 취소해도 상태가 바뀌지 않으므로 재시도해도 안전합니다.
+	resp, err := e.Client.DeleteCtx(client.WithIdempotent(ctx), "/order", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -278,41 +495,234 @@ func (e *Exchange) CancelOrder(params *CancelOrderParams) (*Order, error) {
 	return &order, nil
 }
 
+// CancelOrdersByIDs는 UUID 또는 식별자로 지정한 주문을 일괄 취소합니다.
+// 주문별 성공, 실패 결과를 반환하며 일부 주문이 실패해도 에러를 반환하지 않습니다.
+func (e *Exchange) CancelOrdersByIDs(params *CancelOrdersByIDsParams) (*BatchCancelResult, error) {
+	return e.CancelOrdersByIDsCtx(context.Background(), params)
+}
+
+// CancelOrdersByIDsCtx는 CancelOrdersByIDs와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CancelOrdersByIDsCtx(ctx context.Context, params *CancelOrdersByIDsParams) (*BatchCancelResult, error) {
+	if params == nil {
+		return nil, ErrInvalidParams
+	}
+
+	// UUID와 identifier는 동시에 사용할 수 없습니다.
+	if len(params.UUIDs) > 0 && len(params.Identifiers) > 0 {
+		return nil, errors.New("uuids and identifiers cannot be used together")
+	}
+
+	// UUID 또는 identifier 중 하나는 필수입니다.
+	if len(params.UUIDs) == 0 && len(params.Identifiers) == 0 {
+		return nil, errors.New("either uuids or identifiers must be provided")
+	}
+
+	// 최대 20개까지만 취소 가능합니다.
+	if len(params.UUIDs) > 20 || len(params.Identifiers) > 20 {
+		return nil, ErrTooManyCancelIDs
+	}
+
+	queryParams := make(url.Values)
+	for _, uuid := range params.UUIDs {
+		queryParams.Add("uuids[]", uuid)
+	}
+	for _, identifier := range params.Identifiers {
+		queryParams.Add("identifiers[]", identifier)
+	}
+
+	if e.skipDryRun("CancelOrdersByIDs", params) {
+		var orders []CancelledOrder
+		for _, uuid := range params.UUIDs {
+			orders = append(orders, CancelledOrder{UUID: uuid})
+		}
+		for _, identifier := range params.Identifiers {
+			orders = append(orders, CancelledOrder{Identifier: identifier})
+		}
+		return &BatchCancelResult{Success: CancelResultGroup{Count: len(orders), Orders: orders}}, nil
+	}
+
+	// 이미 취소된 주문은 실패 목록에 포함될 뿐이므로 재시도해도 안전합니다.
+	resp, err := e.Client.DeleteCtx(client.WithIdempotent(ctx), "/orders/uuids", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BatchCancelResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CancelOpenOrders는 조건에 맞는 미체결 주문을 일괄 취소합니다.
+// params가 nil이면 서버 기본값(전체 마켓, 매수/매도 전체, 최대 20개)으로 취소합니다.
+// 주문별 성공, 실패 결과를 반환하며 일부 주문이 실패해도 에러를 반환하지 않습니다.
+func (e *Exchange) CancelOpenOrders(params *CancelOpenOrdersParams) (*BatchCancelResult, error) {
+	return e.CancelOpenOrdersCtx(context.Background(), params)
+}
+
+// CancelOpenOrdersCtx는 CancelOpenOrders와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CancelOpenOrdersCtx(ctx context.Context, params *CancelOpenOrdersParams) (*BatchCancelResult, error) {
+	queryParams := make(url.Values)
+
+	if params != nil {
+		if len(params.Pairs) > 0 && len(params.QuoteCurrencies) > 0 {
+			return nil, errors.New("pairs and quote_currencies cannot be used together")
+		}
+		if len(params.Pairs) > 20 || len(params.ExcludedPairs) > 20 {
+			return nil, errors.New("pairs and excluded_pairs cannot exceed 20")
+		}
+		switch params.CancelSide {
+		case "", CancelSideAll, CancelSideAsk, CancelSideBid:
+		default:
+			return nil, errors.New("cancel_side must be all, ask or bid")
+		}
+
+		if params.CancelSide != "" {
+			queryParams.Set("cancel_side", params.CancelSide)
+		}
+		if len(params.Pairs) > 0 {
+			queryParams.Set("pairs", strings.Join(params.Pairs, ","))
+		}
+		if len(params.ExcludedPairs) > 0 {
+			queryParams.Set("excluded_pairs", strings.Join(params.ExcludedPairs, ","))
+		}
+		if len(params.QuoteCurrencies) > 0 {
+			queryParams.Set("quote_currencies", strings.Join(params.QuoteCurrencies, ","))
+		}
+		if params.Count > 0 {
+			if params.Count > 300 {
+				return nil, errors.New("count cannot exceed 300")
+			}
+			queryParams.Set("count", strconv.Itoa(params.Count))
+		}
+		if params.OrderBy != "" {
+			queryParams.Set("order_by", params.OrderBy)
+		}
+	}
+
+	// 모의 실행 모드에서는 취소 대상을 알 수 없으므로 빈 결과를 반환합니다.
+	if e.skipDryRun("CancelOpenOrders", params) {
+		return &BatchCancelResult{}, nil
+	}
+
+	// 이미 취소된 주문은 다시 취소되지 않으므로 재시도해도 안전합니다.
+	resp, err := e.Client.DeleteCtx(client.WithIdempotent(ctx), "/orders/open", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BatchCancelResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CancelAndNewOrder는 주문을 취소하고 같은 마켓, 같은 방향으로 새 주문을 생성합니다.
+// 서버에서 취소와 생성을 한 번에 처리하므로 CancelOrder 후 CreateOrder를 호출할 때처럼
+// 주문이 없는 구간이 생기지 않습니다.
+func (e *Exchange) CancelAndNewOrder(request *CancelAndNewOrderRequest) (*CancelAndNewOrderResponse, error) {
+	return e.CancelAndNewOrderCtx(context.Background(), request)
+}
+
+// CancelAndNewOrderCtx는 CancelAndNewOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) CancelAndNewOrderCtx(ctx context.Context, request *CancelAndNewOrderRequest) (*CancelAndNewOrderResponse, error) {
+	if request == nil {
+		return nil, ErrInvalidParams
+	}
+
+	if request.PrevOrderUUID == "" && request.PrevOrderIdentifier == "" {
+		return nil, errors.New("either prev_order_uuid or prev_order_identifier must be provided")
+	}
+
+	if request.PrevOrderUUID != "" && request.PrevOrderIdentifier != "" {
+		return nil, errors.New("prev_order_uuid and prev_order_identifier cannot be used together")
+	}
+
+	if request.NewOrderType == "" {
+		return nil, errors.New("new_ord_type is required")
+	}
+
+	// 새 주문의 UUID는 TestOrder처럼 조회할 수 없는 임시 값입니다.
+	if e.skipDryRun("CancelAndNewOrder", request) {
+		return &CancelAndNewOrderResponse{
+			Order:              Order{UUID: request.PrevOrderUUID, Identifier: request.PrevOrderIdentifier, State: OrderStateCancel},
+			NewOrderUUID:       uuid.NewString(),
+			NewOrderIdentifier: request.NewIdentifier,
+		}, nil
+	}
+
+	// 새 주문의 Identifier가 있으면 서버가 중복 생성을 거부하므로 재시도해도 안전합니다.
+	if request.NewIdentifier != "" {
+		ctx = client.WithIdempotent(ctx)
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/orders/cancel_and_new", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CancelAndNewOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetNewOrder는 CancelAndNewOrder로 생성된 새 주문을 체결 목록과 함께 조회합니다.
+// 모의 실행 모드의 응답에 담긴 새 주문 UUID는 임시 값이므로 조회할 수 없습니다.
+func (e *Exchange) GetNewOrder(result *CancelAndNewOrderResponse) (*Order, error) {
+	return e.GetNewOrderCtx(context.Background(), result)
+}
+
+// GetNewOrderCtx는 GetNewOrder와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetNewOrderCtx(ctx context.Context, result *CancelAndNewOrderResponse) (*Order, error) {
+	if result == nil || result.NewOrderUUID == "" {
+		return nil, errors.New("new_order_uuid is required")
+	}
+	return e.GetOrderCtx(ctx, &GetOrderParams{UUID: result.NewOrderUUID})
+}
+
 // GetClosedOrders는 완료된 주문 목록을 조회합니다.
 func (e *Exchange) GetClosedOrders(params *ClosedOrderParams) ([]Order, error) {
-	queryParams := make(map[string]string)
+	return e.GetClosedOrdersCtx(context.Background(), params)
+}
+
+// GetClosedOrdersCtx는 GetClosedOrders와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetClosedOrdersCtx(ctx context.Context, params *ClosedOrderParams) ([]Order, error) {
+	queryParams := make(url.Values)
 
 	if params != nil {
 		if params.Market != "" {
-			queryParams["market"] = params.Market
+			queryParams.Set("market", params.Market)
 		}
 		if params.State != "" {
 			if len(params.States) > 0 {
 				return nil, errors.New("state and states cannot be used together")
 			}
-			queryParams["state"] = params.State
+			queryParams.Set("state", params.State)
 		}
-		if len(params.States) > 0 {
-			statesBytes, err := json.Marshal(params.States)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["states"] = string(statesBytes)
+		for _, state := range params.States {
+			queryParams.Add("states[]", state)
 		}
 		if params.StartTime != nil {
-			queryParams["start_time"] = params.StartTime.Format(time.RFC3339)
+			queryParams.Set("start_time", params.StartTime.Format(time.RFC3339))
 		}
 		if params.EndTime != nil {
-			queryParams["end_time"] = params.EndTime.Format(time.RFC3339)
+			queryParams.Set("end_time", params.EndTime.Format(time.RFC3339))
 		}
 		if params.Limit > 0 {
 			if params.Limit > 1000 {
 				return nil, errors.New("limit cannot exceed 1000")
 			}
-			queryParams["limit"] = strconv.Itoa(params.Limit)
+			queryParams.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.OrderBy != "" {
-			queryParams["order_by"] = params.OrderBy
+			queryParams.Set("order_by", params.OrderBy)
 		}
 
 		// 시간 범위 검증
@@ -324,7 +734,7 @@ func (e *Exchange) GetClosedOrders(params *ClosedOrderParams) ([]Order, error) {
 		}
 	}
 
-	resp, err := e.Client.Get("/orders/closed", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/orders/closed", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -339,40 +749,41 @@ func (e *Exchange) GetClosedOrders(params *ClosedOrderParams) ([]Order, error) {
 
 // GetOpenOrders는 미체결 주문 목록을 조회합니다.
 func (e *Exchange) GetOpenOrders(params *OpenOrderParams) ([]Order, error) {
-	queryParams := make(map[string]string)
+	return e.GetOpenOrdersCtx(context.Background(), params)
+}
+
+// GetOpenOrdersCtx는 GetOpenOrders와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetOpenOrdersCtx(ctx context.Context, params *OpenOrderParams) ([]Order, error) {
+	queryParams := make(url.Values)
 
 	if params != nil {
 		if params.Market != "" {
-			queryParams["market"] = params.Market
+			queryParams.Set("market", params.Market)
 		}
 		if params.State != "" {
 			if len(params.States) > 0 {
 				return nil, errors.New("state and states cannot be used together")
 			}
-			queryParams["state"] = params.State
+			queryParams.Set("state", params.State)
 		}
-		if len(params.States) > 0 {
-			statesBytes, err := json.Marshal(params.States)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["states"] = string(statesBytes)
+		for _, state := range params.States {
+			queryParams.Add("states[]", state)
 		}
 		if params.Page > 0 {
-			queryParams["page"] = strconv.Itoa(params.Page)
+			queryParams.Set("page", strconv.Itoa(params.Page))
 		}
 		if params.Limit > 0 {
 			if params.Limit > 100 {
 				return nil, errors.New("limit cannot exceed 100")
 			}
-			queryParams["limit"] = strconv.Itoa(params.Limit)
+			queryParams.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.OrderBy != "" {
-			queryParams["order_by"] = params.OrderBy
+			queryParams.Set("order_by", params.OrderBy)
 		}
 	}
 
-	resp, err := e.Client.Get("/orders/open", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/orders/open", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -387,16 +798,21 @@ func (e *Exchange) GetOpenOrders(params *OpenOrderParams) ([]Order, error) {
 
 // GetOrderChance는 마켓별 주문 가능 정보를 조회합니다.
 func (e *Exchange) GetOrderChance(market string) (*OrderChance, error) {
+	return e.GetOrderChanceCtx(context.Background(), market)
+}
+
+// GetOrderChanceCtx는 GetOrderChance와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetOrderChanceCtx(ctx context.Context, market string) (*OrderChance, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 
 	// GetAccounts()와 동일한 방식으로 호출
-	resp, err := e.Client.Get("/orders/chance", params)
+	resp, err := e.Client.GetCtx(ctx, "/orders/chance", params)
 	if err != nil {
 		return nil, err
 	}
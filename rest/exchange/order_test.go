@@ -0,0 +1,561 @@
+package exchange_test
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hysuki/go-upbit/decimal"
+	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+	"github.com/hysuki/go-upbit/upbittest"
+)
+
+// newTestExchange는 모의 서버와 그 서버에 연결된 Exchange를 생성합니다.
+func newTestExchange(t *testing.T) (*upbittest.Server, *exchange.Exchange) {
+	t.Helper()
+
+	srv := upbittest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv, srv.Client().GetExchange()
+}
+
+// placeOrder는 KRW-BTC 마켓에 지정가 매수 주문을 생성합니다.
+func placeOrder(t *testing.T, ex *exchange.Exchange, price, volume, identifier string) *exchange.Order {
+	t.Helper()
+
+	order, err := ex.CreateOrder(&exchange.CreateOrderRequest{
+		Market:     "KRW-BTC",
+		Side:       exchange.OrderSideBid,
+		OrderType:  exchange.OrderTypeLimit,
+		Price:      price,
+		Volume:     volume,
+		Identifier: identifier,
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	return order
+}
+
+func TestCancelAndNewOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest
+		filled     float64 // 재주문 전에 체결시킬 수량
+		wantErr    error   // 서버가 반환할 에러 (nil이면 성공)
+		wantLocal  bool    // 요청을 보내기 전에 실패하는지 여부
+		wantVolume string
+		wantPrice  string
+	}{
+		{
+			name: "UUID로 지정",
+			request: func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderUUID: prev.UUID, NewOrderType: exchange.OrderTypeLimit, NewPrice: "41000000", NewVolume: "0.002"}
+			},
+			wantVolume: "0.002", wantPrice: "41000000",
+		},
+		{
+			name: "식별자로 지정",
+			request: func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderIdentifier: "prev", NewOrderType: exchange.OrderTypeLimit, NewPrice: "39000000", NewVolume: "0.001", NewIdentifier: "next"}
+			},
+			wantVolume: "0.001", wantPrice: "39000000",
+		},
+		{
+			name: "잔여 수량으로 재주문",
+			request: func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderUUID: prev.UUID, NewOrderType: exchange.OrderTypeLimit, NewPrice: "41000000", NewVolume: exchange.RemainOnly}
+			},
+			filled:     0.0004,
+			wantVolume: "0.0006", wantPrice: "41000000",
+		},
+		{
+			name: "없는 주문",
+			request: func(*exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderUUID: uuid.NewString(), NewOrderType: exchange.OrderTypeLimit, NewPrice: "41000000", NewVolume: "0.001"}
+			},
+			wantErr: rest.ErrOrderNotFound,
+		},
+		{
+			name: "이전 주문 지정 없음",
+			request: func(*exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{NewOrderType: exchange.OrderTypeLimit}
+			},
+			wantLocal: true,
+		},
+		{
+			name: "UUID와 식별자 함께 지정",
+			request: func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderUUID: prev.UUID, PrevOrderIdentifier: "prev", NewOrderType: exchange.OrderTypeLimit}
+			},
+			wantLocal: true,
+		},
+		{
+			name: "새 주문 타입 없음",
+			request: func(prev *exchange.Order) *exchange.CancelAndNewOrderRequest {
+				return &exchange.CancelAndNewOrderRequest{PrevOrderUUID: prev.UUID}
+			},
+			wantLocal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, ex := newTestExchange(t)
+			prev := placeOrder(t, ex, "40000000", "0.001", "prev")
+			if tt.filled > 0 && !srv.PartialFillOrder(prev.UUID, tt.filled) {
+				t.Fatal("PartialFillOrder failed")
+			}
+			sent := len(srv.Requests())
+
+			request := tt.request(prev)
+			result, err := ex.CancelAndNewOrder(request)
+			if tt.wantLocal {
+				if err == nil {
+					t.Fatal("CancelAndNewOrder succeeded")
+				}
+				if n := len(srv.Requests()); n != sent {
+					t.Errorf("sent %d requests, want none", n-sent)
+				}
+				return
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CancelAndNewOrder error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelAndNewOrder error: %v", err)
+			}
+
+			if result.UUID != prev.UUID || result.State != exchange.OrderStateCancel {
+				t.Errorf("previous order = %s %s, want %s cancel", result.UUID, result.State, prev.UUID)
+			}
+			if result.NewOrderUUID == "" || result.NewOrderIdentifier != request.NewIdentifier {
+				t.Errorf("new order = %q %q, want identifier %q", result.NewOrderUUID, result.NewOrderIdentifier, request.NewIdentifier)
+			}
+
+			next, err := ex.GetNewOrder(result)
+			if err != nil {
+				t.Fatalf("GetNewOrder error: %v", err)
+			}
+			if next.UUID != result.NewOrderUUID || next.Identifier != request.NewIdentifier || next.State != exchange.OrderStateWait {
+				t.Errorf("GetNewOrder = %s %q %s", next.UUID, next.Identifier, next.State)
+			}
+			if next.Side != exchange.OrderSideBid || next.Market != "KRW-BTC" {
+				t.Errorf("GetNewOrder = %s %s, want bid KRW-BTC", next.Side, next.Market)
+			}
+			if !next.Volume.Equal(decimal.RequireFromString(tt.wantVolume)) || !next.Price.Equal(decimal.RequireFromString(tt.wantPrice)) {
+				t.Errorf("GetNewOrder price, volume = %s, %s, want %s, %s", next.Price, next.Volume, tt.wantPrice, tt.wantVolume)
+			}
+		})
+	}
+}
+
+func TestCancelAndNewOrderDryRun(t *testing.T) {
+	srv, ex := newTestExchange(t)
+	prev := placeOrder(t, ex, "40000000", "0.001", "prev")
+
+	var actions []string
+	ex.SetDryRun(true, func(action string, request interface{}) {
+		actions = append(actions, action)
+	})
+	sent := len(srv.Requests())
+
+	result, err := ex.CancelAndNewOrder(&exchange.CancelAndNewOrderRequest{
+		PrevOrderIdentifier: "prev",
+		NewOrderType:        exchange.OrderTypeLimit,
+		NewPrice:            "41000000",
+		NewVolume:           exchange.RemainOnly,
+		NewIdentifier:       "next",
+	})
+	if err != nil {
+		t.Fatalf("CancelAndNewOrder error: %v", err)
+	}
+	if n := len(srv.Requests()); n != sent {
+		t.Errorf("sent %d requests in dry-run mode", n-sent)
+	}
+	if len(actions) != 1 || actions[0] != "CancelAndNewOrder" {
+		t.Errorf("dry-run log = %v", actions)
+	}
+	if result.Identifier != "prev" || result.State != exchange.OrderStateCancel {
+		t.Errorf("previous order = %q %s", result.Identifier, result.State)
+	}
+	if _, err := uuid.Parse(result.NewOrderUUID); err != nil || result.NewOrderUUID == prev.UUID {
+		t.Errorf("NewOrderUUID = %q, want a placeholder UUID", result.NewOrderUUID)
+	}
+	if result.NewOrderIdentifier != "next" {
+		t.Errorf("NewOrderIdentifier = %q, want next", result.NewOrderIdentifier)
+	}
+}
+
+// openTestOrders는 여러 마켓에 매수, 매도 대기 주문을 생성하고 식별자별로 반환합니다.
+// 주문은 btc-bid, eth-bid, btc-ask, eth-btc-ask 순서로 생성됩니다.
+func openTestOrders(t *testing.T, srv *upbittest.Server, ex *exchange.Exchange) map[string]*exchange.Order {
+	t.Helper()
+
+	srv.SetBalance("ETH", 1)
+	requests := []*exchange.CreateOrderRequest{
+		{Market: "KRW-BTC", Side: exchange.OrderSideBid, Price: "40000000", Volume: "0.001", Identifier: "btc-bid"},
+		{Market: "KRW-ETH", Side: exchange.OrderSideBid, Price: "2900000", Volume: "0.01", Identifier: "eth-bid"},
+		{Market: "KRW-BTC", Side: exchange.OrderSideAsk, Price: "60000000", Volume: "0.01", Identifier: "btc-ask"},
+		{Market: "BTC-ETH", Side: exchange.OrderSideAsk, Price: "0.07", Volume: "0.1", Identifier: "eth-btc-ask"},
+	}
+	orders := make(map[string]*exchange.Order, len(requests))
+	for _, request := range requests {
+		request.OrderType = exchange.OrderTypeLimit
+		order, err := ex.CreateOrder(request)
+		if err != nil {
+			t.Fatalf("CreateOrder %s error: %v", request.Identifier, err)
+		}
+		orders[request.Identifier] = order
+	}
+	return orders
+}
+
+// cancelledIdentifiers는 일괄 취소 결과에 포함된 주문의 식별자를 정렬해 반환합니다.
+func cancelledIdentifiers(group exchange.CancelResultGroup) []string {
+	var ids []string
+	for _, o := range group.Orders {
+		ids = append(ids, o.Identifier)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestCancelOrdersByIDs(t *testing.T) {
+	// padUUIDs는 ids 뒤에 없는 주문의 UUID를 덧붙여 n개로 만듭니다.
+	padUUIDs := func(n int, ids ...string) []string {
+		for len(ids) < n {
+			ids = append(ids, uuid.NewString())
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		params      func(orders map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams
+		wantSuccess []string
+		wantFailed  int
+		wantErr     error // 요청을 보내기 전에 반환할 에러 (nil이 아니면 errors.Is로 비교)
+		wantLocal   bool  // 요청을 보내기 전에 실패하는지 여부
+	}{
+		{
+			name: "UUID",
+			params: func(orders map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{UUIDs: []string{orders["btc-bid"].UUID, orders["eth-bid"].UUID}}
+			},
+			wantSuccess: []string{"btc-bid", "eth-bid"},
+		},
+		{
+			name: "식별자와 없는 주문",
+			params: func(map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{Identifiers: []string{"btc-ask", "missing"}}
+			},
+			wantSuccess: []string{"btc-ask"},
+			wantFailed:  1,
+		},
+		{
+			name: "최대 20개",
+			params: func(orders map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{UUIDs: padUUIDs(20, orders["eth-btc-ask"].UUID)}
+			},
+			wantSuccess: []string{"eth-btc-ask"},
+			wantFailed:  19,
+		},
+		{
+			name: "20개 초과",
+			params: func(map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{UUIDs: padUUIDs(21)}
+			},
+			wantErr:   exchange.ErrTooManyCancelIDs,
+			wantLocal: true,
+		},
+		{
+			name: "UUID와 식별자 함께 지정",
+			params: func(orders map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{UUIDs: []string{orders["btc-bid"].UUID}, Identifiers: []string{"btc-ask"}}
+			},
+			wantLocal: true,
+		},
+		{
+			name: "지정 없음",
+			params: func(map[string]*exchange.Order) *exchange.CancelOrdersByIDsParams {
+				return &exchange.CancelOrdersByIDsParams{}
+			},
+			wantLocal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, ex := newTestExchange(t)
+			params := tt.params(openTestOrders(t, srv, ex))
+			sent := len(srv.Requests())
+
+			result, err := ex.CancelOrdersByIDs(params)
+			if tt.wantLocal {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("CancelOrdersByIDs error = %v, want %v", err, tt.wantErr)
+				}
+				if n := len(srv.Requests()); n != sent {
+					t.Errorf("sent %d requests, want none", n-sent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelOrdersByIDs error: %v", err)
+			}
+
+			reqs := srv.Requests()
+			req := reqs[len(reqs)-1]
+			if req.Method != "DELETE" || req.Path != "/orders/uuids" {
+				t.Errorf("request = %s %s, want DELETE /orders/uuids", req.Method, req.Path)
+			}
+			if got := len(req.Params["uuids[]"]) + len(req.Params["identifiers[]"]); got != len(params.UUIDs)+len(params.Identifiers) {
+				t.Errorf("sent %d ids, want %d", got, len(params.UUIDs)+len(params.Identifiers))
+			}
+
+			if got := cancelledIdentifiers(result.Success); !slices.Equal(got, tt.wantSuccess) || result.Success.Count != len(tt.wantSuccess) {
+				t.Errorf("success = %v (count %d), want %v", got, result.Success.Count, tt.wantSuccess)
+			}
+			if result.Failed.Count != tt.wantFailed || len(result.Failed.Orders) != tt.wantFailed {
+				t.Errorf("failed = %d, want %d", result.Failed.Count, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestCancelOpenOrders(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      *exchange.CancelOpenOrdersParams
+		wantQuery   map[string]string // 요청에 포함되어야 하는 쿼리 파라미터
+		wantSuccess []string
+		wantLocal   bool // 요청을 보내기 전에 실패하는지 여부
+	}{
+		{
+			name:        "기본값",
+			params:      nil,
+			wantSuccess: []string{"btc-ask", "btc-bid", "eth-bid", "eth-btc-ask"},
+		},
+		{
+			name:        "매수 주문만",
+			params:      &exchange.CancelOpenOrdersParams{CancelSide: exchange.CancelSideBid},
+			wantQuery:   map[string]string{"cancel_side": "bid"},
+			wantSuccess: []string{"btc-bid", "eth-bid"},
+		},
+		{
+			name:        "매도 주문만",
+			params:      &exchange.CancelOpenOrdersParams{CancelSide: exchange.CancelSideAsk},
+			wantQuery:   map[string]string{"cancel_side": "ask"},
+			wantSuccess: []string{"btc-ask", "eth-btc-ask"},
+		},
+		{
+			name:        "마켓 지정",
+			params:      &exchange.CancelOpenOrdersParams{CancelSide: exchange.CancelSideAll, Pairs: []string{"KRW-BTC", "BTC-ETH"}},
+			wantQuery:   map[string]string{"cancel_side": "all", "pairs": "KRW-BTC,BTC-ETH"},
+			wantSuccess: []string{"btc-ask", "btc-bid", "eth-btc-ask"},
+		},
+		{
+			name:        "기준 화폐와 제외 마켓",
+			params:      &exchange.CancelOpenOrdersParams{QuoteCurrencies: []string{"KRW"}, ExcludedPairs: []string{"KRW-ETH", "KRW-XRP"}},
+			wantQuery:   map[string]string{"quote_currencies": "KRW", "excluded_pairs": "KRW-ETH,KRW-XRP"},
+			wantSuccess: []string{"btc-ask", "btc-bid"},
+		},
+		{
+			name:        "최대 개수",
+			params:      &exchange.CancelOpenOrdersParams{Count: 1},
+			wantQuery:   map[string]string{"count": "1"},
+			wantSuccess: []string{"btc-bid"},
+		},
+		{
+			name:        "최대 개수 300",
+			params:      &exchange.CancelOpenOrdersParams{Count: 300},
+			wantQuery:   map[string]string{"count": "300"},
+			wantSuccess: []string{"btc-ask", "btc-bid", "eth-bid", "eth-btc-ask"},
+		},
+		{name: "최대 개수 초과", params: &exchange.CancelOpenOrdersParams{Count: 301}, wantLocal: true},
+		{name: "잘못된 주문 종류", params: &exchange.CancelOpenOrdersParams{CancelSide: "both"}, wantLocal: true},
+		{name: "마켓과 기준 화폐 함께 지정", params: &exchange.CancelOpenOrdersParams{Pairs: []string{"KRW-BTC"}, QuoteCurrencies: []string{"KRW"}}, wantLocal: true},
+		{name: "마켓 20개 초과", params: &exchange.CancelOpenOrdersParams{Pairs: make([]string, 21)}, wantLocal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, ex := newTestExchange(t)
+			openTestOrders(t, srv, ex)
+			sent := len(srv.Requests())
+
+			result, err := ex.CancelOpenOrders(tt.params)
+			if tt.wantLocal {
+				if err == nil {
+					t.Fatal("CancelOpenOrders succeeded")
+				}
+				if n := len(srv.Requests()); n != sent {
+					t.Errorf("sent %d requests, want none", n-sent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelOpenOrders error: %v", err)
+			}
+
+			reqs := srv.Requests()
+			req := reqs[len(reqs)-1]
+			if req.Method != "DELETE" || req.Path != "/orders/open" {
+				t.Errorf("request = %s %s, want DELETE /orders/open", req.Method, req.Path)
+			}
+			if len(req.Params) != len(tt.wantQuery) {
+				t.Errorf("query = %v, want %v", req.Params, tt.wantQuery)
+			}
+			for key, want := range tt.wantQuery {
+				if got := req.Params.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+
+			if got := cancelledIdentifiers(result.Success); !slices.Equal(got, tt.wantSuccess) || result.Success.Count != len(tt.wantSuccess) {
+				t.Errorf("success = %v (count %d), want %v", got, result.Success.Count, tt.wantSuccess)
+			}
+			if result.Failed.Count != 0 {
+				t.Errorf("failed = %d, want 0", result.Failed.Count)
+			}
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	// scripted는 GET /order가 trades와 체결 정보를 담은 주문을 반환하도록 설정합니다.
+	scripted := func(srv *upbittest.Server, executedVolume, executedFunds string, trades ...map[string]interface{}) {
+		if trades == nil {
+			trades = []map[string]interface{}{}
+		}
+		srv.Handle("GET", "/order", upbittest.JSON(http.StatusOK, map[string]interface{}{
+			"uuid":            "scripted",
+			"side":            "bid",
+			"ord_type":        "limit",
+			"state":           "done",
+			"market":          "KRW-XRP",
+			"price":           "110",
+			"volume":          executedVolume,
+			"executed_volume": executedVolume,
+			"executed_funds":  executedFunds,
+			"trades_count":    len(trades),
+			"trades":          trades,
+		}))
+	}
+	trade := func(price, volume, funds string) map[string]interface{} {
+		return map[string]interface{}{"market": "KRW-XRP", "uuid": uuid.NewString(), "price": price, "volume": volume, "funds": funds, "side": "bid", "trend": "up"}
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(srv *upbittest.Server, ex *exchange.Exchange) *exchange.GetOrderParams
+		wantState  string
+		wantTrades int
+		wantFunds  string
+		wantAvg    string
+		wantErr    error // 서버가 반환할 에러
+		wantLocal  bool  // 요청을 보내기 전에 실패하는지 여부
+	}{
+		{
+			name: "나누어 체결된 주문",
+			setup: func(srv *upbittest.Server, ex *exchange.Exchange) *exchange.GetOrderParams {
+				order := placeOrder(t, ex, "40000000", "0.001", "")
+				srv.PartialFillOrder(order.UUID, 0.0004)
+				srv.PartialFillOrder(order.UUID, 0.0006)
+				return &exchange.GetOrderParams{UUID: order.UUID}
+			},
+			wantState: exchange.OrderStateDone, wantTrades: 2, wantFunds: "40000", wantAvg: "40000000",
+		},
+		{
+			name: "식별자로 조회한 미체결 주문",
+			setup: func(srv *upbittest.Server, ex *exchange.Exchange) *exchange.GetOrderParams {
+				placeOrder(t, ex, "40000000", "0.001", "open")
+				return &exchange.GetOrderParams{Identifier: "open"}
+			},
+			wantState: exchange.OrderStateWait, wantTrades: 0, wantFunds: "0", wantAvg: "0",
+		},
+		{
+			name: "가격이 다른 체결",
+			setup: func(srv *upbittest.Server, ex *exchange.Exchange) *exchange.GetOrderParams {
+				scripted(srv, "2", "215", trade("100", "0.5", "50"), trade("110", "1.5", "165"))
+				return &exchange.GetOrderParams{UUID: "scripted"}
+			},
+			wantState: exchange.OrderStateDone, wantTrades: 2, wantFunds: "215", wantAvg: "107.5",
+		},
+		{
+			name: "체결 목록 없이 체결 정보만 있는 주문",
+			setup: func(srv *upbittest.Server, ex *exchange.Exchange) *exchange.GetOrderParams {
+				scripted(srv, "2", "300")
+				return &exchange.GetOrderParams{UUID: "scripted"}
+			},
+			wantState: exchange.OrderStateDone, wantTrades: 0, wantFunds: "300", wantAvg: "150",
+		},
+		{
+			name: "없는 주문",
+			setup: func(*upbittest.Server, *exchange.Exchange) *exchange.GetOrderParams {
+				return &exchange.GetOrderParams{UUID: uuid.NewString()}
+			},
+			wantErr: rest.ErrOrderNotFound,
+		},
+		{
+			name: "UUID와 식별자 함께 지정",
+			setup: func(*upbittest.Server, *exchange.Exchange) *exchange.GetOrderParams {
+				return &exchange.GetOrderParams{UUID: "a", Identifier: "b"}
+			},
+			wantLocal: true,
+		},
+		{
+			name: "지정 없음",
+			setup: func(*upbittest.Server, *exchange.Exchange) *exchange.GetOrderParams {
+				return &exchange.GetOrderParams{}
+			},
+			wantLocal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, ex := newTestExchange(t)
+			params := tt.setup(srv, ex)
+			sent := len(srv.Requests())
+
+			order, err := ex.GetOrder(params)
+			if tt.wantLocal {
+				if err == nil {
+					t.Fatal("GetOrder succeeded")
+				}
+				if n := len(srv.Requests()); n != sent {
+					t.Errorf("sent %d requests, want none", n-sent)
+				}
+				return
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetOrder error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetOrder error: %v", err)
+			}
+
+			if order.State != tt.wantState || len(order.Trades) != tt.wantTrades {
+				t.Errorf("GetOrder = %s with %d trades, want %s with %d", order.State, len(order.Trades), tt.wantState, tt.wantTrades)
+			}
+			if got := order.FilledFunds(); !got.Equal(decimal.RequireFromString(tt.wantFunds)) {
+				t.Errorf("FilledFunds = %s, want %s", got, tt.wantFunds)
+			}
+			if got := order.AverageFillPrice(); !got.Equal(decimal.RequireFromString(tt.wantAvg)) {
+				t.Errorf("AverageFillPrice = %s, want %s", got, tt.wantAvg)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Package exchange는 Upbit 거래소의 출금 관련 API를 제공합니다.
@@ -34,17 +38,17 @@ const (
 
 // WithdrawInfo는 출금 정보를 나타냅니다.
 type WithdrawInfo struct {
-	Type            string    `json:"type,omitempty"`             // 입출금 종류
-	UUID            string    `json:"uuid,omitempty"`             // 출금의 고유 ID
-	Currency        string    `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
-	NetType         string    `json:"net_type,omitempty"`         // 출금 네트워크
-	TxID            string    `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
-	State           string    `json:"state,omitempty"`            // 출금 상태
-	CreatedAt       time.Time `json:"created_at,omitempty"`       // 출금 생성 시각
-	DoneAt          time.Time `json:"done_at,omitempty"`          // 출금 완료 시각
-	Amount          string    `json:"amount,omitempty"`           // 출금 금액/수량
-	Fee             string    `json:"fee,omitempty"`              // 출금 수수료
-	TransactionType string    `json:"transaction_type,omitempty"` // 출금 유형
+	Type            string          `json:"type,omitempty"`             // 입출금 종류
+	UUID            string          `json:"uuid,omitempty"`             // 출금의 고유 ID
+	Currency        string          `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
+	NetType         string          `json:"net_type,omitempty"`         // 출금 네트워크
+	TxID            string          `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
+	State           string          `json:"state,omitempty"`            // 출금 상태
+	CreatedAt       time.Time       `json:"created_at"`                 // 출금 생성 시각
+	DoneAt          time.Time       `json:"done_at"`                    // 출금 완료 시각
+	Amount          decimal.Decimal `json:"amount"`                     // 출금 금액/수량
+	Fee             decimal.Decimal `json:"fee"`                        // 출금 수수료
+	TransactionType string          `json:"transaction_type,omitempty"` // 출금 유형
 }
 
 // WithdrawAddress는 출금 허용 주소 정보를 나타냅니다.
@@ -70,22 +74,22 @@ type MemberLevel struct {
 
 // WithdrawCurrency는 화폐 정보를 나타냅니다.
 type WithdrawCurrency struct {
-	Code          string   `json:"code,omitempty"`           // 화폐를 의미하는 영문 대문자 코드
-	WithdrawFee   string   `json:"withdraw_fee,omitempty"`   // 출금 수수료
-	IsCoin        bool     `json:"is_coin,omitempty"`        // 디지털 자산 여부
-	WalletState   string   `json:"wallet_state,omitempty"`   // 지갑 상태
-	WalletSupport []string `json:"wallet_support,omitempty"` // 지원하는 입출금 정보
+	Code          string          `json:"code,omitempty"`           // 화폐를 의미하는 영문 대문자 코드
+	WithdrawFee   decimal.Decimal `json:"withdraw_fee"`             // 출금 수수료
+	IsCoin        bool            `json:"is_coin,omitempty"`        // 디지털 자산 여부
+	WalletState   string          `json:"wallet_state,omitempty"`   // 지갑 상태
+	WalletSupport []string        `json:"wallet_support,omitempty"` // 지원하는 입출금 정보
 }
 
 // WithdrawLimit는 출금 제약 정보를 나타냅니다.
 type WithdrawLimit struct {
-	Currency            string `json:"currency,omitempty"`              // 화폐를 의미하는 영문 대문자 코드
-	Minimum             string `json:"minimum,omitempty"`               // 최소 출금 금액/수량
-	RemainingDailyFiat  string `json:"remaining_daily_fiat,omitempty"`  // 통합 1일 잔여 출금 한도
-	FiatCurrency        string `json:"fiat_currency,omitempty"`         // 구매 가능한 법정 화폐
-	WithdrawDelayedFiat string `json:"withdraw_delayed_fiat,omitempty"` // 출금지연제로 인한 제한 금액
-	Fixed               int    `json:"fixed,omitempty"`                 // 출금 금액/수량 소수점 자리 수
-	CanWithdraw         bool   `json:"can_withdraw,omitempty"`          // 출금 지원 여부
+	Currency            string          `json:"currency,omitempty"`      // 화폐를 의미하는 영문 대문자 코드
+	Minimum             decimal.Decimal `json:"minimum"`                 // 최소 출금 금액/수량
+	RemainingDailyFiat  decimal.Decimal `json:"remaining_daily_fiat"`    // 통합 1일 잔여 출금 한도
+	FiatCurrency        string          `json:"fiat_currency,omitempty"` // 구매 가능한 법정 화폐
+	WithdrawDelayedFiat decimal.Decimal `json:"withdraw_delayed_fiat"`   // 출금지연제로 인한 제한 금액
+	Fixed               int             `json:"fixed,omitempty"`         // 출금 금액/수량 소수점 자리 수
+	CanWithdraw         bool            `json:"can_withdraw,omitempty"`  // 출금 지원 여부
 }
 
 // WithdrawChance는 출금 가능 정보를 나타냅니다.
@@ -104,16 +108,16 @@ type WithdrawKRWParams struct {
 
 // WithdrawKRWResponse는 원화 출금 요청에 대한 응답입니다.
 type WithdrawKRWResponse struct {
-	Type            string `json:"type,omitempty"`             // 입출금 종류
-	UUID            string `json:"uuid,omitempty"`             // 출금의 고유 ID
-	Currency        string `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
-	TxID            string `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
-	State           string `json:"state,omitempty"`            // 출금 상태
-	CreatedAt       string `json:"created_at,omitempty"`       // 출금 생성 시각
-	DoneAt          string `json:"done_at,omitempty"`          // 출금 완료 시각
-	Amount          string `json:"amount,omitempty"`           // 출금 금액/수량
-	Fee             string `json:"fee,omitempty"`              // 출금 수수료
-	TransactionType string `json:"transaction_type,omitempty"` // 출금 유형
+	Type            string          `json:"type,omitempty"`             // 입출금 종류
+	UUID            string          `json:"uuid,omitempty"`             // 출금의 고유 ID
+	Currency        string          `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
+	TxID            string          `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
+	State           string          `json:"state,omitempty"`            // 출금 상태
+	CreatedAt       time.Time       `json:"created_at"`                 // 출금 생성 시각
+	DoneAt          time.Time       `json:"done_at"`                    // 출금 완료 시각
+	Amount          decimal.Decimal `json:"amount"`                     // 출금 금액/수량
+	Fee             decimal.Decimal `json:"fee"`                        // 출금 수수료
+	TransactionType string          `json:"transaction_type,omitempty"` // 출금 유형
 }
 
 // WithdrawCoinParams는 디지털 자산 출금을 위한 파라미터입니다.
@@ -128,18 +132,18 @@ type WithdrawCoinParams struct {
 
 // WithdrawCoinResponse는 디지털 자산 출금 요청에 대한 응답입니다.
 type WithdrawCoinResponse struct {
-	Type            string `json:"type,omitempty"`             // 입출금 종류
-	UUID            string `json:"uuid,omitempty"`             // 출금의 고유 ID
-	Currency        string `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
-	NetType         string `json:"net_type,omitempty"`         // 출금 네트워크
-	TxID            string `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
-	State           string `json:"state,omitempty"`            // 출금 상태
-	CreatedAt       string `json:"created_at,omitempty"`       // 출금 생성 시각
-	DoneAt          string `json:"done_at,omitempty"`          // 출금 완료 시각
-	Amount          string `json:"amount,omitempty"`           // 출금 금액/수량
-	Fee             string `json:"fee,omitempty"`              // 출금 수수료
-	KrwAmount       string `json:"krw_amount,omitempty"`       // 원화 환산 가격
-	TransactionType string `json:"transaction_type,omitempty"` // 출금 유형
+	Type            string          `json:"type,omitempty"`             // 입출금 종류
+	UUID            string          `json:"uuid,omitempty"`             // 출금의 고유 ID
+	Currency        string          `json:"currency,omitempty"`         // 화폐를 의미하는 영문 대문자 코드
+	NetType         string          `json:"net_type,omitempty"`         // 출금 네트워크
+	TxID            string          `json:"txid,omitempty"`             // 출금의 트랜잭션 ID
+	State           string          `json:"state,omitempty"`            // 출금 상태
+	CreatedAt       time.Time       `json:"created_at"`                 // 출금 생성 시각
+	DoneAt          time.Time       `json:"done_at"`                    // 출금 완료 시각
+	Amount          decimal.Decimal `json:"amount"`                     // 출금 금액/수량
+	Fee             decimal.Decimal `json:"fee"`                        // 출금 수수료
+	KrwAmount       decimal.Decimal `json:"krw_amount"`                 // 원화 환산 가격
+	TransactionType string          `json:"transaction_type,omitempty"` // 출금 유형
 }
 
 // GetWithdrawParams는 개별 출금 조회를 위한 파라미터입니다.
@@ -162,7 +166,12 @@ type WithdrawListParams struct {
 
 // GetWithdrawAddresses는 등록된 출금 허용 주소 목록을 조회합니다.
 func (e *Exchange) GetWithdrawAddresses() ([]WithdrawAddress, error) {
-	resp, err := e.Client.Get("/withdraws/coin_addresses", nil)
+	return e.GetWithdrawAddressesCtx(context.Background())
+}
+
+// GetWithdrawAddressesCtx는 GetWithdrawAddresses와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetWithdrawAddressesCtx(ctx context.Context) ([]WithdrawAddress, error) {
+	resp, err := e.Client.GetCtx(ctx, "/withdraws/coin_addresses", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,15 +186,20 @@ func (e *Exchange) GetWithdrawAddresses() ([]WithdrawAddress, error) {
 
 // GetWithdrawChance는 해당 통화의 출금 가능 정보를 조회합니다.
 func (e *Exchange) GetWithdrawChance(currency string) (*WithdrawChance, error) {
+	return e.GetWithdrawChanceCtx(context.Background(), currency)
+}
+
+// GetWithdrawChanceCtx는 GetWithdrawChance와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetWithdrawChanceCtx(ctx context.Context, currency string) (*WithdrawChance, error) {
 	if currency == "" {
 		return nil, errors.New("currency is required")
 	}
 
-	params := map[string]string{
-		"currency": currency,
+	params := url.Values{
+		"currency": {currency},
 	}
 
-	resp, err := e.Client.Get("/withdraws/chance", params)
+	resp, err := e.Client.GetCtx(ctx, "/withdraws/chance", params)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +214,11 @@ func (e *Exchange) GetWithdrawChance(currency string) (*WithdrawChance, error) {
 
 // WithdrawKRW는 원화 출금을 요청합니다.
 func (e *Exchange) WithdrawKRW(params *WithdrawKRWParams) (*WithdrawKRWResponse, error) {
+	return e.WithdrawKRWCtx(context.Background(), params)
+}
+
+// WithdrawKRWCtx는 WithdrawKRW와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) WithdrawKRWCtx(ctx context.Context, params *WithdrawKRWParams) (*WithdrawKRWResponse, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -220,7 +239,12 @@ func (e *Exchange) WithdrawKRW(params *WithdrawKRWParams) (*WithdrawKRWResponse,
 		return nil, errors.New("invalid two_factor_type")
 	}
 
-	resp, err := e.Client.Post("/withdraws/krw", params)
+	if e.skipDryRun("WithdrawKRW", params) {
+		amount, _ := decimal.NewFromString(params.Amount)
+		return &WithdrawKRWResponse{Type: "withdraw", Currency: "KRW", Amount: amount, CreatedAt: time.Now()}, nil
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/withdraws/krw", params)
 	if err != nil {
 		return nil, err
 	}
@@ -235,6 +259,11 @@ func (e *Exchange) WithdrawKRW(params *WithdrawKRWParams) (*WithdrawKRWResponse,
 
 // WithdrawCoin은 디지털 자산 출금을 요청합니다.
 func (e *Exchange) WithdrawCoin(params *WithdrawCoinParams) (*WithdrawCoinResponse, error) {
+	return e.WithdrawCoinCtx(context.Background(), params)
+}
+
+// WithdrawCoinCtx는 WithdrawCoin와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) WithdrawCoinCtx(ctx context.Context, params *WithdrawCoinParams) (*WithdrawCoinResponse, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -253,7 +282,19 @@ func (e *Exchange) WithdrawCoin(params *WithdrawCoinParams) (*WithdrawCoinRespon
 		return nil, errors.New("address is required")
 	}
 
-	resp, err := e.Client.Post("/withdraws/coin", params)
+	if e.skipDryRun("WithdrawCoin", params) {
+		amount, _ := decimal.NewFromString(params.Amount)
+		return &WithdrawCoinResponse{
+			Type:            "withdraw",
+			Currency:        params.Currency,
+			NetType:         params.NetType,
+			Amount:          amount,
+			CreatedAt:       time.Now(),
+			TransactionType: params.TransactionType,
+		}, nil
+	}
+
+	resp, err := e.Client.PostCtx(ctx, "/withdraws/coin", params)
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +309,11 @@ func (e *Exchange) WithdrawCoin(params *WithdrawCoinParams) (*WithdrawCoinRespon
 
 // GetWithdraw는 출금 UUID를 통해 개별 출금 정보를 조회합니다.
 func (e *Exchange) GetWithdraw(params *GetWithdrawParams) (*WithdrawInfo, error) {
+	return e.GetWithdrawCtx(context.Background(), params)
+}
+
+// GetWithdrawCtx는 GetWithdraw와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetWithdrawCtx(ctx context.Context, params *GetWithdrawParams) (*WithdrawInfo, error) {
 	if params == nil {
 		return nil, errors.New("params cannot be nil")
 	}
@@ -277,18 +323,18 @@ func (e *Exchange) GetWithdraw(params *GetWithdrawParams) (*WithdrawInfo, error)
 		return nil, errors.New("either uuid, txid, or currency must be provided")
 	}
 
-	queryParams := make(map[string]string)
+	queryParams := make(url.Values)
 	if params.UUID != "" {
-		queryParams["uuid"] = params.UUID
+		queryParams.Set("uuid", params.UUID)
 	}
 	if params.TxID != "" {
-		queryParams["txid"] = params.TxID
+		queryParams.Set("txid", params.TxID)
 	}
 	if params.Currency != "" {
-		queryParams["currency"] = params.Currency
+		queryParams.Set("currency", params.Currency)
 	}
 
-	resp, err := e.Client.Get("/withdraw", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/withdraw", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -303,44 +349,41 @@ func (e *Exchange) GetWithdraw(params *GetWithdrawParams) (*WithdrawInfo, error)
 
 // GetWithdraws는 출금 리스트를 조회합니다.
 func (e *Exchange) GetWithdraws(params *WithdrawListParams) ([]WithdrawInfo, error) {
-	queryParams := make(map[string]string)
+	return e.GetWithdrawsCtx(context.Background(), params)
+}
+
+// GetWithdrawsCtx는 GetWithdraws와 같지만 요청에 ctx를 사용합니다.
+func (e *Exchange) GetWithdrawsCtx(ctx context.Context, params *WithdrawListParams) ([]WithdrawInfo, error) {
+	queryParams := make(url.Values)
 
 	if params != nil {
 		if params.Currency != "" {
-			queryParams["currency"] = params.Currency
+			queryParams.Set("currency", params.Currency)
 		}
 		if params.State != "" {
-			queryParams["state"] = params.State
+			queryParams.Set("state", params.State)
 		}
-		if len(params.UUIDs) > 0 {
-			uuidsBytes, err := json.Marshal(params.UUIDs)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["uuids"] = string(uuidsBytes)
+		for _, uuid := range params.UUIDs {
+			queryParams.Add("uuids[]", uuid)
 		}
-		if len(params.TxIDs) > 0 {
-			txidsBytes, err := json.Marshal(params.TxIDs)
-			if err != nil {
-				return nil, err
-			}
-			queryParams["txids"] = string(txidsBytes)
+		for _, txid := range params.TxIDs {
+			queryParams.Add("txids[]", txid)
 		}
 		if params.Limit > 0 {
 			if params.Limit > 100 {
 				params.Limit = 100
 			}
-			queryParams["limit"] = strconv.Itoa(params.Limit)
+			queryParams.Set("limit", strconv.Itoa(params.Limit))
 		}
 		if params.Page > 0 {
-			queryParams["page"] = strconv.Itoa(params.Page)
+			queryParams.Set("page", strconv.Itoa(params.Page))
 		}
 		if params.OrderBy != "" {
-			queryParams["order_by"] = params.OrderBy
+			queryParams.Set("order_by", params.OrderBy)
 		}
 	}
 
-	resp, err := e.Client.Get("/withdraws", queryParams)
+	resp, err := e.Client.GetCtx(ctx, "/withdraws", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,387 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hysuki/go-upbit/auth"
+)
+
+// ErrNoAvailableKeys는 키 풀에 사용할 수 있는 API 키가 없을 때 반환됩니다.
+var ErrNoAvailableKeys = errors.New("no available API keys in pool")
+
+// TokenFeedback은 토큰을 사용한 요청의 결과를 전달받는 TokenGenerator가 구현하는 인터페이스입니다.
+// 클라이언트는 인증이 필요한 요청을 시도할 때마다 사용한 토큰(Authorization 헤더 값),
+// 응답의 잔여 요청 수 정보 rr(없으면 nil), 요청 결과 err(성공하면 nil)로 Report를 호출합니다.
+type TokenFeedback interface {
+	Report(token string, rr *RemainingReq, err error)
+}
+
+// GroupTokenGenerator는 요청 그룹에 따라 토큰을 생성하는 TokenGenerator가 구현하는 인터페이스입니다.
+// 클라이언트는 인증이 필요한 요청마다 요청 수 제한 그룹(GroupOrder 등)과 함께
+// GenerateTokenForGroup을 호출하며, 구현하지 않은 생성기는 GenerateTokenWithQuery로 토큰을 만듭니다.
+type GroupTokenGenerator interface {
+	GenerateTokenForGroup(group string, query url.Values) (string, error)
+}
+
+// KeyStrategy는 키 풀이 요청에 사용할 키를 고르는 방식입니다.
+type KeyStrategy int
+
+// 키 선택 방식을 정의하는 상수들입니다.
+const (
+	KeyRoundRobin    KeyStrategy = iota // 사용 가능한 키를 차례대로 사용
+	KeyMostRemaining                    // 요청 그룹의 잔여 요청 수가 가장 많은 키를 사용
+)
+
+// excludedKeyErrors는 키를 풀에서 제외하는 에러 이름입니다.
+// 키 자체가 더 이상 유효하지 않아 다시 시도해도 성공할 수 없는 경우입니다.
+var excludedKeyErrors = map[ErrorCode]bool{
+	ErrJWTVerification:  true,
+	ErrExpiredAccessKey: true,
+	ErrInvalidAccessKey: true,
+}
+
+// KeyStatus는 키 풀에 속한 키의 상태입니다.
+type KeyStatus struct {
+	AccessKey    string                  // 액세스 키
+	Healthy      bool                    // 요청에 사용 가능한지 여부
+	Err          error                   // 풀에서 제외된 원인 (사용 가능하면 nil)
+	Requests     int                     // 결과를 전달받은 요청 수
+	Failures     int                     // 연속 실패 횟수
+	LastUsed     time.Time               // 마지막으로 토큰을 생성한 시각
+	RemainingReq map[string]RemainingReq // 그룹별 마지막 잔여 요청 수 정보
+}
+
+// poolKey는 키 풀에 속한 키 하나의 상태입니다.
+type poolKey struct {
+	accessKey string
+	gen       *auth.RestTokenGen
+	err       error                     // 제외된 원인 (nil이면 사용 가능)
+	requests  int                       // 결과를 전달받은 요청 수
+	failures  int                       // 연속 실패 횟수
+	lastUsed  time.Time                 // 마지막으로 토큰을 생성한 시각
+	groups    map[string]*poolKeyBudget // 그룹별 잔여 요청 수
+}
+
+// poolKeyBudget은 키 하나의 요청 그룹별 잔여 요청 수 상태입니다.
+type poolKeyBudget struct {
+	remaining *RemainingReq // 마지막으로 수신한 잔여 요청 수 정보
+	issued    int           // remaining 수신 이후 생성한 토큰 수
+}
+
+// KeyPool은 여러 API 키로 요청을 나누어 보내는 TokenGenerator입니다.
+// 같은 계정의 키를 여러 개 사용해 요청 수 제한을 분산할 때 사용합니다.
+//
+// 요청 결과를 TokenFeedback으로 전달받아 키별 잔여 요청 수와 상태를 추적하며,
+// jwt_verification, expired_access_key, invalid_access_key 에러를 반환한 키는
+// Enable로 다시 활성화할 때까지 사용하지 않습니다.
+//
+// 클라이언트의 요청 수 제한기는 키를 구분하지 않으므로, 키 풀을 사용할 때는
+// WithRateLimitMode(RateLimitDisabled)로 제한을 끄고 KeyMostRemaining 방식을 사용하는 것이 좋습니다.
+//
+//	pool, err := rest.NewKeyPool([]auth.Credentials{key1, key2}, rest.WithKeyStrategy(rest.KeyMostRemaining))
+//	client := rest.NewClient(pool, rest.WithRateLimitMode(rest.RateLimitDisabled))
+type KeyPool struct {
+	mu         sync.Mutex
+	keys       []*poolKey
+	byAccess   map[string]*poolKey
+	strategy   KeyStrategy
+	next       int                               // 다음 차례의 키 인덱스
+	onExcluded func(accessKey string, err error) // 키가 제외될 때 호출할 함수
+	now        func() time.Time
+}
+
+// KeyPoolOption은 키 풀의 설정을 변경하는 함수 타입입니다.
+type KeyPoolOption func(*KeyPool)
+
+// WithKeyStrategy는 키 선택 방식을 설정하는 옵션을 반환합니다.
+// 기본값은 KeyRoundRobin입니다.
+func WithKeyStrategy(strategy KeyStrategy) KeyPoolOption {
+	return func(p *KeyPool) {
+		p.strategy = strategy
+	}
+}
+
+// WithKeyExcludedHandler는 키가 풀에서 제외될 때 호출할 함수를 설정하는 옵션을 반환합니다.
+// 키 만료 알림 등에 사용하며, fn은 요청을 처리하는 고루틴에서 호출됩니다.
+func WithKeyExcludedHandler(fn func(accessKey string, err error)) KeyPoolOption {
+	return func(p *KeyPool) {
+		p.onExcluded = fn
+	}
+}
+
+// NewKeyPool은 creds의 키를 사용하는 키 풀을 생성합니다.
+// 키가 없거나, 비어 있거나, 액세스 키가 중복되면 에러를 반환합니다.
+func NewKeyPool(creds []auth.Credentials, opts ...KeyPoolOption) (*KeyPool, error) {
+	if len(creds) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+
+	p := &KeyPool{
+		byAccess: make(map[string]*poolKey, len(creds)),
+		strategy: KeyRoundRobin,
+		now:      time.Now,
+	}
+	for i, c := range creds {
+		if c.AccessKey == "" || c.SecretKey == "" {
+			return nil, fmt.Errorf("key %d: access key and secret key are required", i)
+		}
+		if _, ok := p.byAccess[c.AccessKey]; ok {
+			return nil, fmt.Errorf("key %d: duplicate access key", i)
+		}
+		k := &poolKey{
+			accessKey: c.AccessKey,
+			gen:       auth.NewRestTokenGen(c),
+			groups:    make(map[string]*poolKeyBudget),
+		}
+		p.keys = append(p.keys, k)
+		p.byAccess[c.AccessKey] = k
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// GenerateToken은 풀에서 고른 키로 기본 JWT 토큰을 생성합니다.
+func (p *KeyPool) GenerateToken() (string, error) {
+	k, err := p.pick(GroupDefault)
+	if err != nil {
+		return "", err
+	}
+	return k.gen.GenerateToken()
+}
+
+// GenerateTokenWithQuery는 풀에서 고른 키로 쿼리 파라미터를 포함한 JWT 토큰을 생성합니다.
+func (p *KeyPool) GenerateTokenWithQuery(query url.Values) (string, error) {
+	return p.GenerateTokenForGroup(GroupDefault, query)
+}
+
+// GenerateTokenWithBody는 풀에서 고른 키로 JSON 본문을 포함한 JWT 토큰을 생성합니다.
+func (p *KeyPool) GenerateTokenWithBody(body string) (string, error) {
+	k, err := p.pick(GroupDefault)
+	if err != nil {
+		return "", err
+	}
+	return k.gen.GenerateTokenWithBody(body)
+}
+
+// GenerateTokenForGroup은 group 요청에 사용할 키를 골라 쿼리 파라미터를 포함한 JWT 토큰을 생성합니다.
+func (p *KeyPool) GenerateTokenForGroup(group string, query url.Values) (string, error) {
+	k, err := p.pick(group)
+	if err != nil {
+		return "", err
+	}
+	return k.gen.GenerateTokenWithQuery(query)
+}
+
+// pick은 group 요청에 사용할 키를 고르고 사용 기록을 남깁니다.
+func (p *KeyPool) pick(group string) (*poolKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var (
+		chosen *poolKey
+		index  int
+		best   int
+	)
+	// next부터 차례대로 확인하므로 잔여 요청 수가 같으면 라운드 로빈으로 고릅니다.
+	for i := 0; i < len(p.keys); i++ {
+		idx := (p.next + i) % len(p.keys)
+		k := p.keys[idx]
+		if k.err != nil {
+			continue
+		}
+		if p.strategy != KeyMostRemaining {
+			chosen, index = k, idx
+			break
+		}
+		if budget := k.budget(group, now); chosen == nil || budget > best {
+			chosen, index, best = k, idx, budget
+		}
+	}
+	if chosen == nil {
+		return nil, fmt.Errorf("%w: all %d keys are excluded", ErrNoAvailableKeys, len(p.keys))
+	}
+
+	p.next = (index + 1) % len(p.keys)
+	chosen.lastUsed = now
+	chosen.state(group).issued++
+	return chosen, nil
+}
+
+// Report는 token으로 보낸 요청의 결과로 키 상태를 갱신합니다.
+// 키를 무효화하는 에러를 받으면 해당 키를 풀에서 제외합니다.
+func (p *KeyPool) Report(token string, rr *RemainingReq, err error) {
+	accessKey := tokenAccessKey(token)
+
+	p.mu.Lock()
+	k, ok := p.byAccess[accessKey]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+
+	k.requests++
+	if rr != nil {
+		s := k.state(rr.Group)
+		s.remaining = rr
+		s.issued = 0
+	}
+
+	var excluded error
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		k.failures = 0
+	case errors.As(err, &apiErr) && excludedKeyErrors[apiErr.Code()]:
+		k.failures++
+		if k.err == nil {
+			k.err = err
+			excluded = err
+		}
+	default:
+		k.failures++
+	}
+	onExcluded := p.onExcluded
+	p.mu.Unlock()
+
+	if excluded != nil && onExcluded != nil {
+		onExcluded(accessKey, excluded)
+	}
+}
+
+// Exclude는 accessKey를 풀에서 제외합니다. reason은 KeyStatus.Err로 확인할 수 있습니다.
+// 풀에 없는 키이면 false를 반환합니다.
+func (p *KeyPool) Exclude(accessKey string, reason error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k, ok := p.byAccess[accessKey]
+	if !ok {
+		return false
+	}
+	if reason == nil {
+		reason = errors.New("excluded manually")
+	}
+	k.err = reason
+	return true
+}
+
+// Enable은 제외된 accessKey를 다시 사용하도록 합니다.
+// 풀에 없는 키이면 false를 반환합니다.
+func (p *KeyPool) Enable(accessKey string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k, ok := p.byAccess[accessKey]
+	if !ok {
+		return false
+	}
+	k.err = nil
+	k.failures = 0
+	return true
+}
+
+// Keys는 풀에 속한 키들의 상태를 추가된 순서대로 반환합니다.
+func (p *KeyPool) Keys() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make([]KeyStatus, 0, len(p.keys))
+	for _, k := range p.keys {
+		status := KeyStatus{
+			AccessKey:    k.accessKey,
+			Healthy:      k.err == nil,
+			Err:          k.err,
+			Requests:     k.requests,
+			Failures:     k.failures,
+			LastUsed:     k.lastUsed,
+			RemainingReq: make(map[string]RemainingReq, len(k.groups)),
+		}
+		for group, s := range k.groups {
+			if s.remaining != nil {
+				status.RemainingReq[group] = *s.remaining
+			}
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// state는 잠금을 획득한 상태에서 그룹 상태를 반환하며, 없으면 생성합니다.
+func (k *poolKey) state(group string) *poolKeyBudget {
+	s, ok := k.groups[group]
+	if !ok {
+		s = &poolKeyBudget{}
+		k.groups[group] = s
+	}
+	return s
+}
+
+// budget은 잠금을 획득한 상태에서 group 요청에 사용할 수 있는 잔여 요청 수를 추정합니다.
+// 1초 안에 받은 잔여 요청 수 정보가 없으면 그룹의 기본 한도를 사용합니다.
+func (k *poolKey) budget(group string, now time.Time) int {
+	limit, ok := defaultGroupLimits[group]
+	if !ok {
+		limit = defaultGroupLimits[GroupDefault]
+	}
+
+	s, ok := k.groups[group]
+	if !ok || s.remaining == nil {
+		return limit
+	}
+	rr := s.remaining
+	if rr.Min == 0 && now.Sub(rr.UpdatedAt) < time.Minute {
+		return 0
+	}
+	if now.Sub(rr.UpdatedAt) >= time.Second {
+		return limit
+	}
+	return rr.Sec - s.issued
+}
+
+// tokenAccessKey는 Authorization 헤더 값의 JWT 페이로드에서 access_key를 꺼냅니다.
+// 형식이 잘못되었으면 빈 문자열을 반환합니다.
+func tokenAccessKey(token string) string {
+	parts := strings.Split(strings.TrimPrefix(token, "Bearer "), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		AccessKey string `json:"access_key"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.AccessKey
+}
+
+// rateLimitGroupKey는 요청의 요청 수 제한 그룹을 컨텍스트에 저장할 때 사용하는 키입니다.
+type rateLimitGroupKey struct{}
+
+// withRateLimitGroup은 요청 그룹을 담은 컨텍스트를 반환합니다.
+// GroupTokenGenerator가 그룹별 잔여 요청 수로 키를 고를 때 사용합니다.
+func withRateLimitGroup(ctx context.Context, group string) context.Context {
+	return context.WithValue(ctx, rateLimitGroupKey{}, group)
+}
+
+// rateLimitGroup은 ctx에 저장된 요청 그룹을 반환합니다. 없으면 GroupDefault를 반환합니다.
+func rateLimitGroup(ctx context.Context) string {
+	if group, ok := ctx.Value(rateLimitGroupKey{}).(string); ok {
+		return group
+	}
+	return GroupDefault
+}
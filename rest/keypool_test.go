@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hysuki/go-upbit/auth"
+)
+
+// newTestKeyPool은 액세스 키가 accessKeys인 키 풀과 직접 조정할 수 있는 시각을 생성합니다.
+func newTestKeyPool(t *testing.T, accessKeys []string, opts ...KeyPoolOption) (*KeyPool, *time.Time) {
+	t.Helper()
+
+	creds := make([]auth.Credentials, len(accessKeys))
+	for i, ak := range accessKeys {
+		creds[i] = auth.Credentials{AccessKey: ak, SecretKey: ak + "-secret"}
+	}
+	p, err := NewKeyPool(creds, opts...)
+	if err != nil {
+		t.Fatalf("NewKeyPool error: %v", err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+// nextKey는 group 요청에 대해 키 풀이 고른 키의 액세스 키를 반환합니다.
+func nextKey(t *testing.T, p *KeyPool, group string) string {
+	t.Helper()
+
+	token, err := p.GenerateTokenForGroup(group, nil)
+	if err != nil {
+		t.Fatalf("GenerateTokenForGroup error: %v", err)
+	}
+	return tokenAccessKey(token)
+}
+
+func TestNewKeyPoolErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		creds []auth.Credentials
+	}{
+		{name: "키 없음", creds: nil},
+		{name: "시크릿 키 없음", creds: []auth.Credentials{{AccessKey: "a"}}},
+		{name: "중복된 액세스 키", creds: []auth.Credentials{{AccessKey: "a", SecretKey: "x"}, {AccessKey: "a", SecretKey: "y"}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewKeyPool(tt.creds); err == nil {
+			t.Errorf("%s: NewKeyPool succeeded", tt.name)
+		}
+	}
+}
+
+func TestKeyPoolRoundRobin(t *testing.T) {
+	p, _ := newTestKeyPool(t, []string{"a", "b", "c"})
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, nextKey(t, p, GroupDefault))
+	}
+	if want := []string{"a", "b", "c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+
+	// 제외된 키는 건너뜁니다.
+	p.Exclude("c", nil)
+	got = got[:0]
+	for i := 0; i < 3; i++ {
+		got = append(got, nextKey(t, p, GroupOrder))
+	}
+	if want := []string{"b", "a", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("keys after Exclude = %v, want %v", got, want)
+	}
+}
+
+func TestKeyPoolMostRemaining(t *testing.T) {
+	p, now := newTestKeyPool(t, []string{"a", "b", "c"}, WithKeyStrategy(KeyMostRemaining))
+
+	report := func(accessKey, header string) {
+		t.Helper()
+		rr, err := ParseRemainingReq(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr.UpdatedAt = *now
+		token, err := p.byAccess[accessKey].gen.GenerateToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Report(token, rr, nil)
+	}
+	report("a", "group=order; min=100; sec=2")
+	report("b", "group=order; min=100; sec=5")
+	report("c", "group=order; min=0; sec=7")
+
+	// c는 분당 잔여 요청을 모두 사용했으므로 잔여 요청 수가 가장 많은 b를 고릅니다.
+	// b로 토큰을 생성할 때마다 추정 잔여 요청 수가 줄어 a와 같아지면 차례대로 고릅니다.
+	var got []string
+	for i := 0; i < 5; i++ {
+		got = append(got, nextKey(t, p, GroupOrder))
+	}
+	if want := []string{"b", "b", "b", "a", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("order keys = %v, want %v", got, want)
+	}
+
+	// 다른 그룹은 잔여 요청 수 정보가 없으므로 기본 한도로 차례대로 고릅니다.
+	if got := nextKey(t, p, GroupDefault); got != "c" {
+		t.Errorf("default key = %s, want c", got)
+	}
+
+	// 1초가 지나면 초당 잔여 요청 수는 다시 기본 한도가 되지만, 분당 잔여 요청이 없는 c는 제외됩니다.
+	*now = now.Add(time.Second)
+	got = got[:0]
+	for i := 0; i < 2; i++ {
+		got = append(got, nextKey(t, p, GroupOrder))
+	}
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("order keys after 1s = %v, want %v", got, want)
+	}
+}
+
+func TestKeyPoolReportExcludes(t *testing.T) {
+	tests := []struct {
+		code    ErrorCode
+		exclude bool
+	}{
+		{code: ErrJWTVerification, exclude: true},
+		{code: ErrExpiredAccessKey, exclude: true},
+		{code: ErrInvalidAccessKey, exclude: true},
+		{code: ErrNonceUsed, exclude: false},
+		{code: ErrTooManyRequests, exclude: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			var excluded []string
+			p, _ := newTestKeyPool(t, []string{"a", "b"}, WithKeyExcludedHandler(func(accessKey string, err error) {
+				excluded = append(excluded, accessKey)
+			}))
+
+			token, err := p.GenerateToken()
+			if err != nil {
+				t.Fatal(err)
+			}
+			apiErr := &APIError{Name: string(tt.code), StatusCode: http.StatusUnauthorized}
+			p.Report(token, nil, apiErr)
+			p.Report(token, nil, apiErr)
+
+			status := p.Keys()[0]
+			if status.Healthy == tt.exclude {
+				t.Errorf("Healthy = %v, want %v", status.Healthy, !tt.exclude)
+			}
+			if status.Failures != 2 || status.Requests != 2 {
+				t.Errorf("Failures = %d, Requests = %d, want 2, 2", status.Failures, status.Requests)
+			}
+			if !tt.exclude {
+				if len(excluded) != 0 {
+					t.Errorf("excluded handler called with %v", excluded)
+				}
+				return
+			}
+
+			// 제외 처리기는 키가 처음 제외될 때 한 번만 호출됩니다.
+			if !slices.Equal(excluded, []string{"a"}) {
+				t.Errorf("excluded handler called with %v, want [a]", excluded)
+			}
+			if !errors.Is(status.Err, tt.code) {
+				t.Errorf("Err = %v, want %s", status.Err, tt.code)
+			}
+			for i := 0; i < 2; i++ {
+				if got := nextKey(t, p, GroupDefault); got != "b" {
+					t.Fatalf("key after exclusion = %s, want b", got)
+				}
+			}
+
+			p.Exclude("b", nil)
+			if _, err := p.GenerateToken(); !errors.Is(err, ErrNoAvailableKeys) {
+				t.Fatalf("GenerateToken with all keys excluded = %v, want ErrNoAvailableKeys", err)
+			}
+
+			p.Enable("a")
+			if status := p.Keys()[0]; !status.Healthy || status.Failures != 0 {
+				t.Errorf("status after Enable = %+v", status)
+			}
+			if got := nextKey(t, p, GroupDefault); got != "a" {
+				t.Errorf("key after Enable = %s, want a", got)
+			}
+		})
+	}
+}
+
+func TestKeyPoolClient(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ak := tokenAccessKey(r.Header.Get("Authorization"))
+		keys = append(keys, ak)
+		if ak == "bad" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"name":"invalid_access_key","message":"잘못된 엑세스 키입니다."}}`))
+			return
+		}
+		w.Header().Set(RemainingReqHeader, "group=order; min=1799; sec=7")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	p, _ := newTestKeyPool(t, []string{"bad", "good"}, WithKeyStrategy(KeyMostRemaining))
+	c := NewClient(p, WithBaseURL(srv.URL), WithRateLimitMode(RateLimitDisabled))
+
+	if _, err := c.Post("/orders", map[string]interface{}{"market": "KRW-BTC"}); !errors.Is(err, ErrInvalidAccessKey) {
+		t.Fatalf("first request error = %v, want invalid_access_key", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Post("/orders", map[string]interface{}{"market": "KRW-BTC"}); err != nil {
+			t.Fatalf("request %d error: %v", i+2, err)
+		}
+	}
+	if want := []string{"bad", "good", "good"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+
+	// 클라이언트는 요청 그룹별로 잔여 요청 수를 전달합니다.
+	if rr, ok := p.Keys()[1].RemainingReq[GroupOrder]; !ok || rr.Sec != 7 {
+		t.Errorf("good key order RemainingReq = %+v, %v", rr, ok)
+	}
+}
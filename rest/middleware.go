@@ -0,0 +1,37 @@
+package rest
+
+import "net/http"
+
+// RoundTrip은 HTTP 요청 하나를 실행하고 응답을 반환하는 함수입니다.
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Middleware는 RoundTrip을 감싸 요청과 응답을 가로채는 함수입니다.
+// 로깅, 메트릭 수집, 감사 기록, 서명 변경, 장애 주입 등에 사용합니다.
+//
+//	logging := func(next rest.RoundTrip) rest.RoundTrip {
+//		return func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next(req)
+//			log.Printf("%s %s (%v)", req.Method, req.URL.Path, time.Since(start))
+//			return resp, err
+//		}
+//	}
+type Middleware func(next RoundTrip) RoundTrip
+
+// WithMiddleware는 요청 실행 경로에 미들웨어를 추가하는 옵션을 반환합니다.
+// 먼저 추가된 미들웨어가 바깥쪽에서 실행되며, 미들웨어는 인증 헤더가 설정된
+// 요청을 전달받고 재시도할 때마다 다시 호출됩니다.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// chain은 미들웨어들로 final을 감싼 RoundTrip을 생성합니다.
+func chain(middlewares []Middleware, final RoundTrip) RoundTrip {
+	rt := final
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
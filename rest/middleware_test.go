@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// recorder는 요청 전후에 이름을 events에 기록하는 미들웨어를 반환합니다.
+func recorder(name string, events *[]string) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			*events = append(*events, name+" 요청")
+			resp, err := next(req)
+			*events = append(*events, name+" 응답")
+			return resp, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var events []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events = append(events, "서버 "+r.Header.Get("X-Trace"))
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// 미들웨어는 인증 헤더가 설정된 요청을 받으며 요청을 바꿀 수 있습니다.
+	var authorization string
+	tracing := func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			req.Header.Set("X-Trace", "trace-id")
+			return next(req)
+		}
+	}
+
+	c := newTestClient(srv, WithMiddleware(recorder("a", &events), recorder("b", &events)), WithMiddleware(tracing))
+	if _, err := c.Get("/accounts", nil); err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+
+	want := []string{"a 요청", "b 요청", "서버 trace-id", "b 응답", "a 응답"}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		t.Errorf("Authorization seen by middleware = %q, want a bearer token", authorization)
+	}
+}
+
+func TestMiddlewareRetry(t *testing.T) {
+	srv, calls := newStatusServer(t, http.StatusServiceUnavailable)
+
+	// 재시도할 때마다 미들웨어가 다시 호출됩니다.
+	var events []string
+	c := newTestClient(srv, WithRetryPolicy(fastRetry(3)), WithMiddleware(recorder("a", &events)))
+	if _, err := c.Get("/accounts", nil); err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if want := []string{"a 요청", "a 응답", "a 요청", "a 응답"}; !slices.Equal(events, want) || calls.Load() != 2 {
+		t.Errorf("events = %v with %d calls, want %v", events, calls.Load(), want)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	errInjected := errors.New("injected")
+
+	tests := []struct {
+		name     string
+		rt       RoundTrip
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name: "응답 대체",
+			rt: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{RemainingReqHeader: {"group=default; min=1799; sec=29"}},
+					Body:       io.NopCloser(strings.NewReader(`[{"currency":"KRW"}]`)),
+				}, nil
+			},
+			wantBody: `[{"currency":"KRW"}]`,
+		},
+		{
+			name: "에러 주입",
+			rt: func(req *http.Request) (*http.Response, error) {
+				return nil, errInjected
+			},
+			wantErr: errInjected,
+		},
+		{
+			name: "에러 응답 주입",
+			rt: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("Too Many Requests")),
+				}, nil
+			},
+			wantErr: ErrTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newStatusServer(t)
+
+			var events []string
+			shortCircuit := func(next RoundTrip) RoundTrip { return tt.rt }
+			c := newTestClient(srv, WithMiddleware(recorder("a", &events), shortCircuit, recorder("b", &events)))
+
+			body, err := c.Get("/accounts", nil)
+			if calls.Load() != 0 {
+				t.Errorf("server received %d requests, want none", calls.Load())
+			}
+			if want := []string{"a 요청", "a 응답"}; !slices.Equal(events, want) {
+				t.Errorf("events = %v, want %v", events, want)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Get error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || string(body) != tt.wantBody {
+				t.Errorf("Get = %s, %v, want %s", body, err, tt.wantBody)
+			}
+		})
+	}
+}
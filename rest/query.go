@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// encodeBody는 POST 요청 본문을 form 데이터와 query_hash 계산에 사용할 url.Values로 변환합니다.
+// body는 url.Values, map[string]interface{} 또는 JSON으로 마샬링할 수 있는 구조체입니다.
+// 배열 값은 Upbit API 형식에 맞게 "key[]"를 반복하여 인코딩합니다.
+func encodeBody(body interface{}) (url.Values, error) {
+	switch b := body.(type) {
+	case nil:
+		return url.Values{}, nil
+	case url.Values:
+		return b, nil
+	case map[string]interface{}:
+		return toValues(b), nil
+	}
+
+	// 구조체를 JSON으로 마샬링 후 다시 map으로 언마샬링
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal body: %w", err)
+	}
+
+	// 숫자가 float64로 바뀌며 정밀도를 잃지 않도록 json.Number로 디코딩
+	var bodyMap map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+	dec.UseNumber()
+	if err := dec.Decode(&bodyMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body to map: %w", err)
+	}
+
+	return toValues(bodyMap), nil
+}
+
+// toValues는 map을 url.Values로 변환합니다. nil 값은 제외합니다.
+func toValues(m map[string]interface{}) url.Values {
+	values := make(url.Values, len(m))
+	for key, v := range m {
+		switch v := v.(type) {
+		case nil:
+		case []interface{}:
+			key = arrayKey(key)
+			for _, item := range v {
+				values.Add(key, formatValue(item))
+			}
+		case []string:
+			key = arrayKey(key)
+			for _, item := range v {
+				values.Add(key, item)
+			}
+		default:
+			values.Add(key, formatValue(v))
+		}
+	}
+	return values
+}
+
+// arrayKey는 배열 파라미터 이름에 "[]" 접미사를 붙입니다.
+func arrayKey(key string) string {
+	if strings.HasSuffix(key, "[]") {
+		return key
+	}
+	return key + "[]"
+}
+
+// formatValue는 단일 값을 쿼리 문자열 값으로 변환합니다.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		// encoding/json은 아주 작거나 큰 실수를 지수 표기법(1e-08)으로 마샬링합니다.
+		if strings.ContainsAny(v.String(), "eE") {
+			if f, err := v.Float64(); err == nil {
+				return strconv.FormatFloat(f, 'f', -1, 64)
+			}
+		}
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
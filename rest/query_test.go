@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hysuki/go-upbit/decimal"
+)
+
+func TestToValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  url.Values
+	}{
+		{
+			name:  "빈 map",
+			input: map[string]interface{}{},
+			want:  url.Values{},
+		},
+		{
+			name:  "nil 값 제외",
+			input: map[string]interface{}{"market": "KRW-BTC", "state": nil},
+			want:  url.Values{"market": {"KRW-BTC"}},
+		},
+		{
+			name:  "문자열 배열",
+			input: map[string]interface{}{"uuids": []string{"a", "b"}},
+			want:  url.Values{"uuids[]": {"a", "b"}},
+		},
+		{
+			name:  "이미 [] 접미사가 있는 배열",
+			input: map[string]interface{}{"states[]": []interface{}{"wait", "watch"}},
+			want:  url.Values{"states[]": {"wait", "watch"}},
+		},
+		{
+			name:  "숫자 배열",
+			input: map[string]interface{}{"ids": []interface{}{json.Number("1"), json.Number("2")}},
+			want:  url.Values{"ids[]": {"1", "2"}},
+		},
+		{
+			name:  "빈 배열",
+			input: map[string]interface{}{"uuids": []string{}},
+			want:  url.Values{},
+		},
+		{
+			name: "단일 값 형식",
+			input: map[string]interface{}{
+				"price":  json.Number("1e-08"),
+				"volume": json.Number("0.00012345"),
+				"limit":  float64(100),
+				"ratio":  0.5,
+				"page":   3,
+				"flag":   true,
+			},
+			want: url.Values{
+				"price":  {"0.00000001"},
+				"volume": {"0.00012345"},
+				"limit":  {"100"},
+				"ratio":  {"0.5"},
+				"page":   {"3"},
+				"flag":   {"true"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toValues(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toValues = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeBody(t *testing.T) {
+	type orderBody struct {
+		Market     string          `json:"market"`
+		Side       string          `json:"side"`
+		Volume     string          `json:"volume,omitempty"`
+		Price      decimal.Decimal `json:"price"`
+		Identifier *string         `json:"identifier,omitempty"`
+		UUIDs      []string        `json:"uuids,omitempty"`
+		Count      int             `json:"count"`
+		Small      float64         `json:"small"`
+	}
+
+	tests := []struct {
+		name    string
+		body    interface{}
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			body: nil,
+			want: url.Values{},
+		},
+		{
+			name: "url.Values는 그대로 사용",
+			body: url.Values{"market": {"KRW-BTC"}, "states[]": {"wait"}},
+			want: url.Values{"market": {"KRW-BTC"}, "states[]": {"wait"}},
+		},
+		{
+			name: "map",
+			body: map[string]interface{}{"market": "KRW-BTC", "uuids": []string{"a"}},
+			want: url.Values{"market": {"KRW-BTC"}, "uuids[]": {"a"}},
+		},
+		{
+			name: "구조체",
+			body: orderBody{
+				Market: "KRW-BTC",
+				Side:   "bid",
+				Price:  decimal.RequireFromString("0.00000001"),
+				UUIDs:  []string{"a", "b"},
+				Count:  2,
+				Small:  1e-8,
+			},
+			want: url.Values{
+				"market":  {"KRW-BTC"},
+				"side":    {"bid"},
+				"price":   {"0.00000001"},
+				"uuids[]": {"a", "b"},
+				"count":   {"2"},
+				"small":   {"0.00000001"},
+			},
+		},
+		{
+			name: "큰 정수의 정밀도 유지",
+			body: struct {
+				ID int64 `json:"id"`
+			}{ID: 9007199254740993},
+			want: url.Values{"id": {"9007199254740993"}},
+		},
+		{
+			name:    "마샬링할 수 없는 값",
+			body:    struct{ C chan int }{C: make(chan int)},
+			wantErr: true,
+		},
+		{
+			name:    "객체가 아닌 JSON",
+			body:    []string{"a"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeBody(tt.body)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("encodeBody = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("encodeBody error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeBody = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package quotation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Package quotation은 Upbit 거래소의 시세 조회 관련 API를 제공합니다.
@@ -22,28 +27,85 @@ const (
 
 // Candle은 캔들 정보를 나타냅니다.
 type Candle struct {
-	Market               string  `json:"market"`                          // 마켓명
-	CandleDateTimeUTC    string  `json:"candle_date_time_utc"`            // 캔들 기준 시각(UTC)
-	CandleDateTimeKST    string  `json:"candle_date_time_kst"`            // 캔들 기준 시각(KST)
-	OpeningPrice         float64 `json:"opening_price"`                   // 시가
-	HighPrice            float64 `json:"high_price"`                      // 고가
-	LowPrice             float64 `json:"low_price"`                       // 저가
-	TradePrice           float64 `json:"trade_price"`                     // 종가
-	Timestamp            int64   `json:"timestamp"`                       // 마지막 틱이 저장된 시각
-	CandleAccTradePrice  float64 `json:"candle_acc_trade_price"`          // 누적 거래 금액
-	CandleAccTradeVolume float64 `json:"candle_acc_trade_volume"`         // 누적 거래량
-	FirstDayOfPeriod     string  `json:"first_day_of_period,omitempty"`   // 캔들 기간의 첫 날
-	Unit                 int     `json:"unit,omitempty"`                  // 분봉 단위
-	ConvertedTradePrice  float64 `json:"converted_trade_price,omitempty"` // 종가 환산 화폐 단위로 환산된 가격
-	PrevClosingPrice     float64 `json:"prev_closing_price,omitempty"`    // 전일 종가
-	ChangePrice          float64 `json:"change_price,omitempty"`          // 전일 종가 대비 변화 금액
-	ChangeRate           float64 `json:"change_rate,omitempty"`           // 전일 종가 대비 변화량
+	Market               string          `json:"market"`                        // 마켓명
+	CandleDateTimeUTC    time.Time       `json:"candle_date_time_utc"`          // 캔들 기준 시각 (UTC)
+	CandleDateTimeKST    time.Time       `json:"candle_date_time_kst"`          // 캔들 기준 시각 (KST)
+	OpeningPrice         decimal.Decimal `json:"opening_price"`                 // 시가
+	HighPrice            decimal.Decimal `json:"high_price"`                    // 고가
+	LowPrice             decimal.Decimal `json:"low_price"`                     // 저가
+	TradePrice           decimal.Decimal `json:"trade_price"`                   // 종가
+	Timestamp            time.Time       `json:"timestamp"`                     // 마지막 틱이 저장된 시각 (UTC)
+	CandleAccTradePrice  decimal.Decimal `json:"candle_acc_trade_price"`        // 누적 거래 금액
+	CandleAccTradeVolume decimal.Decimal `json:"candle_acc_trade_volume"`       // 누적 거래량
+	FirstDayOfPeriod     string          `json:"first_day_of_period,omitempty"` // 캔들 기간의 첫 날
+	Unit                 int             `json:"unit,omitempty"`                // 분봉 단위
+	ConvertedTradePrice  decimal.Decimal `json:"converted_trade_price"`         // 종가 환산 화폐 단위로 환산된 가격
+	PrevClosingPrice     decimal.Decimal `json:"prev_closing_price"`            // 전일 종가
+	ChangePrice          decimal.Decimal `json:"change_price"`                  // 전일 종가 대비 변화 금액
+	ChangeRate           decimal.Decimal `json:"change_rate"`                   // 전일 종가 대비 변화량
+}
+
+// upbitCandle은 Candle의 시각 필드를 API 응답 형식으로 주고받기 위한 구조체입니다.
+type upbitCandle struct {
+	*candleAlias
+	CandleDateTimeUTC string `json:"candle_date_time_utc"`
+	CandleDateTimeKST string `json:"candle_date_time_kst"`
+	Timestamp         int64  `json:"timestamp"`
+}
+
+// candleAlias는 Candle의 JSON 메서드가 재귀 호출되지 않도록 하는 별칭입니다.
+type candleAlias Candle
+
+// UnmarshalJSON은 시간대가 없는 캔들 기준 시각을 각각 UTC와 KST로 해석하고,
+// 밀리초 단위 타임스탬프를 UTC 시각으로 변환합니다.
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	aux := upbitCandle{candleAlias: (*candleAlias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if c.CandleDateTimeUTC, err = parseTime(candleTimeLayout, aux.CandleDateTimeUTC, time.UTC); err != nil {
+		return err
+	}
+	if c.CandleDateTimeKST, err = parseTime(candleTimeLayout, aux.CandleDateTimeKST, KST); err != nil {
+		return err
+	}
+	c.Timestamp = fromUnixMilli(aux.Timestamp)
+	return nil
+}
+
+// MarshalJSON은 시각 필드를 API 응답과 같은 형식으로 인코딩합니다.
+func (c Candle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(upbitCandle{
+		candleAlias:       (*candleAlias)(&c),
+		CandleDateTimeUTC: formatTime(candleTimeLayout, c.CandleDateTimeUTC, time.UTC),
+		CandleDateTimeKST: formatTime(candleTimeLayout, c.CandleDateTimeKST, KST),
+		Timestamp:         toUnixMilli(c.Timestamp),
+	})
+}
+
+// In은 Timestamp를 loc 시간대로 변환한 캔들을 반환합니다.
+// loc이 nil이면 UTC를 사용합니다. CandleDateTimeUTC와 CandleDateTimeKST는
+// 이름에 해당하는 시간대를 유지합니다.
+func (c Candle) In(loc *time.Location) Candle {
+	// loc이 nil인 경우 UTC를 사용
+	if loc == nil {
+		loc = time.UTC
+	}
+	c.Timestamp = inLocation(c.Timestamp, loc)
+	return c
 }
 
 // GetCandlesMinute는 분(Minute) 캔들을 조회합니다.
 // unit은 분봉 단위(1, 3, 5, 10, 15, 30, 60, 240)를 지정합니다.
 // market은 마켓 코드, to는 마지막 캔들 시각, count는 조회할 캔들 개수입니다.
 func (q *Quotation) GetCandlesMinute(unit int, market string, to string, count int) ([]Candle, error) {
+	return q.GetCandlesMinuteCtx(context.Background(), unit, market, to, count)
+}
+
+// GetCandlesMinuteCtx는 GetCandlesMinute와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetCandlesMinuteCtx(ctx context.Context, unit int, market string, to string, count int) ([]Candle, error) {
 	if !isValidMinuteUnit(unit) {
 		return nil, fmt.Errorf("invalid minute unit: %d", unit)
 	}
@@ -54,17 +116,17 @@ func (q *Quotation) GetCandlesMinute(unit int, market string, to string, count i
 		count = 200
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 
-	resp, err := q.Client.Get(fmt.Sprintf("/candles/minutes/%d", unit), params)
+	resp, err := q.get(ctx, fmt.Sprintf("/candles/minutes/%d", unit), params)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +143,11 @@ func (q *Quotation) GetCandlesMinute(unit int, market string, to string, count i
 // market은 마켓 코드, to는 마지막 캔들 시각, count는 조회할 캔들 개수입니다.
 // convertingPriceUnit으로 종가 환산 화폐 단위를 지정할 수 있습니다.
 func (q *Quotation) GetCandlesDay(market string, to string, count int, convertingPriceUnit string) ([]Candle, error) {
+	return q.GetCandlesDayCtx(context.Background(), market, to, count, convertingPriceUnit)
+}
+
+// GetCandlesDayCtx는 GetCandlesDay와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetCandlesDayCtx(ctx context.Context, market string, to string, count int, convertingPriceUnit string) ([]Candle, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
@@ -88,20 +155,20 @@ func (q *Quotation) GetCandlesDay(market string, to string, count int, convertin
 		count = 200
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 	if convertingPriceUnit != "" {
-		params["converting_price_unit"] = convertingPriceUnit
+		params.Set("converting_price_unit", convertingPriceUnit)
 	}
 
-	resp, err := q.Client.Get("/candles/days", params)
+	resp, err := q.get(ctx, "/candles/days", params)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +184,11 @@ func (q *Quotation) GetCandlesDay(market string, to string, count int, convertin
 // GetCandlesWeek는 주(Week) 캔들을 조회합니다.
 // market은 마켓 코드, to는 마지막 캔들 시각, count는 조회할 캔들 개수입니다.
 func (q *Quotation) GetCandlesWeek(market string, to string, count int) ([]Candle, error) {
+	return q.GetCandlesWeekCtx(context.Background(), market, to, count)
+}
+
+// GetCandlesWeekCtx는 GetCandlesWeek와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetCandlesWeekCtx(ctx context.Context, market string, to string, count int) ([]Candle, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
@@ -124,17 +196,17 @@ func (q *Quotation) GetCandlesWeek(market string, to string, count int) ([]Candl
 		count = 200
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 
-	resp, err := q.Client.Get("/candles/weeks", params)
+	resp, err := q.get(ctx, "/candles/weeks", params)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +222,11 @@ func (q *Quotation) GetCandlesWeek(market string, to string, count int) ([]Candl
 // GetCandlesMonth는 월(Month) 캔들을 조회합니다.
 // market은 마켓 코드, to는 마지막 캔들 시각, count는 조회할 캔들 개수입니다.
 func (q *Quotation) GetCandlesMonth(market string, to string, count int) ([]Candle, error) {
+	return q.GetCandlesMonthCtx(context.Background(), market, to, count)
+}
+
+// GetCandlesMonthCtx는 GetCandlesMonth와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetCandlesMonthCtx(ctx context.Context, market string, to string, count int) ([]Candle, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
@@ -157,17 +234,17 @@ func (q *Quotation) GetCandlesMonth(market string, to string, count int) ([]Cand
 		count = 200
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 
-	resp, err := q.Client.Get("/candles/months", params)
+	resp, err := q.get(ctx, "/candles/months", params)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +260,11 @@ func (q *Quotation) GetCandlesMonth(market string, to string, count int) ([]Cand
 // GetCandlesYear는 년(Year) 캔들을 조회합니다.
 // market은 마켓 코드, to는 마지막 캔들 시각, count는 조회할 캔들 개수입니다.
 func (q *Quotation) GetCandlesYear(market string, to string, count int) ([]Candle, error) {
+	return q.GetCandlesYearCtx(context.Background(), market, to, count)
+}
+
+// GetCandlesYearCtx는 GetCandlesYear와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetCandlesYearCtx(ctx context.Context, market string, to string, count int) ([]Candle, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
@@ -190,17 +272,17 @@ func (q *Quotation) GetCandlesYear(market string, to string, count int) ([]Candl
 		count = 200
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 
-	resp, err := q.Client.Get("/candles/years", params)
+	resp, err := q.get(ctx, "/candles/years", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,9 @@
 package quotation
 
 import (
+	"context"
 	"encoding/json"
+	"net/url"
 )
 
 // Package quotation은 Upbit 거래소의 시세 조회 관련 API를 제공합니다.
@@ -33,12 +35,17 @@ type MarketCaution struct {
 // GetMarkets는 업비트에서 거래 가능한 마켓 목록을 조회합니다.
 // isDetails가 true인 경우 마켓 이벤트 정보를 포함하여 반환합니다.
 func (q *Quotation) GetMarkets(isDetails bool) ([]MarketInfo, error) {
-	params := make(map[string]string)
+	return q.GetMarketsCtx(context.Background(), isDetails)
+}
+
+// GetMarketsCtx는 GetMarkets와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetMarketsCtx(ctx context.Context, isDetails bool) ([]MarketInfo, error) {
+	params := make(url.Values)
 	if isDetails {
-		params["is_details"] = "true"
+		params.Set("is_details", "true")
 	}
 
-	resp, err := q.Client.Get("/market/all", params)
+	resp, err := q.get(ctx, "/market/all", params)
 	if err != nil {
 		return nil, err
 	}
@@ -1,52 +1,100 @@
 package quotation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // OrderbookUnit은 호가 정보를 나타냅니다.
 type OrderbookUnit struct {
-	AskPrice float64 `json:"ask_price"` // 매도 호가
-	BidPrice float64 `json:"bid_price"` // 매수 호가
-	AskSize  float64 `json:"ask_size"`  // 매도 잔량
-	BidSize  float64 `json:"bid_size"`  // 매수 잔량
+	AskPrice decimal.Decimal `json:"ask_price"` // 매도 호가
+	BidPrice decimal.Decimal `json:"bid_price"` // 매수 호가
+	AskSize  decimal.Decimal `json:"ask_size"`  // 매도 잔량
+	BidSize  decimal.Decimal `json:"bid_size"`  // 매수 잔량
 }
 
 // Orderbook은 호가 정보를 나타냅니다.
 type Orderbook struct {
 	Market         string          `json:"market"`          // 마켓 코드
-	Timestamp      int64           `json:"timestamp"`       // 호가 생성 시각
-	TotalAskSize   float64         `json:"total_ask_size"`  // 호가 매도 총 잔량
-	TotalBidSize   float64         `json:"total_bid_size"`  // 호가 매수 총 잔량
+	Timestamp      time.Time       `json:"timestamp"`       // 호가 생성 시각 (UTC)
+	TotalAskSize   decimal.Decimal `json:"total_ask_size"`  // 호가 매도 총 잔량
+	TotalBidSize   decimal.Decimal `json:"total_bid_size"`  // 호가 매수 총 잔량
 	OrderbookUnits []OrderbookUnit `json:"orderbook_units"` // 호가 정보
-	Level          float64         `json:"level"`           // 호가 모아보기 단위 (0: 기본 호가단위)
+	Level          decimal.Decimal `json:"level"`           // 호가 모아보기 단위 (0: 기본 호가단위)
+}
+
+// upbitOrderbook은 Orderbook의 시각 필드를 API 응답 형식으로 주고받기 위한 구조체입니다.
+type upbitOrderbook struct {
+	*orderbookAlias
+	Timestamp int64 `json:"timestamp"`
+}
+
+// orderbookAlias는 Orderbook의 JSON 메서드가 재귀 호출되지 않도록 하는 별칭입니다.
+type orderbookAlias Orderbook
+
+// UnmarshalJSON은 밀리초 단위 타임스탬프를 UTC 시각으로 변환합니다.
+func (o *Orderbook) UnmarshalJSON(data []byte) error {
+	aux := upbitOrderbook{orderbookAlias: (*orderbookAlias)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.Timestamp = fromUnixMilli(aux.Timestamp)
+	return nil
+}
+
+// MarshalJSON은 시각 필드를 API 응답과 같은 형식으로 인코딩합니다.
+func (o Orderbook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(upbitOrderbook{
+		orderbookAlias: (*orderbookAlias)(&o),
+		Timestamp:      toUnixMilli(o.Timestamp),
+	})
+}
+
+// In은 Timestamp를 loc 시간대로 변환한 호가 정보를 반환합니다.
+// loc이 nil이면 UTC를 사용합니다.
+func (o Orderbook) In(loc *time.Location) Orderbook {
+	// loc이 nil인 경우 UTC를 사용
+	if loc == nil {
+		loc = time.UTC
+	}
+	o.Timestamp = inLocation(o.Timestamp, loc)
+	return o
 }
 
 // SupportedLevel은 호가 모아보기 단위 정보를 나타냅니다.
 type SupportedLevel struct {
-	Market          string    `json:"market"`           // 마켓 코드
-	SupportedLevels []float64 `json:"supported_levels"` // 지원하는 모아보기 단위 (0: 기본 호가단위)
+	Market          string            `json:"market"`           // 마켓 코드
+	SupportedLevels []decimal.Decimal `json:"supported_levels"` // 지원하는 모아보기 단위 (0: 기본 호가단위)
 }
 
 // GetOrderbooks는 호가 정보를 조회합니다.
 // markets는 마켓 코드 목록, level은 호가 모아보기 단위입니다.
 func (q *Quotation) GetOrderbooks(markets []string, level float64) ([]Orderbook, error) {
+	return q.GetOrderbooksCtx(context.Background(), markets, level)
+}
+
+// GetOrderbooksCtx는 GetOrderbooks와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetOrderbooksCtx(ctx context.Context, markets []string, level float64) ([]Orderbook, error) {
 	if len(markets) == 0 {
 		return nil, errors.New("markets is required")
 	}
 
-	params := map[string]string{
-		"markets": strings.Join(markets, ","),
+	params := url.Values{
+		"markets": {strings.Join(markets, ",")},
 	}
 
 	if level != 0 {
-		params["level"] = fmt.Sprintf("%v", level)
+		params.Set("level", fmt.Sprintf("%v", level))
 	}
 
-	resp, err := q.Client.Get("/orderbook", params)
+	resp, err := q.get(ctx, "/orderbook", params)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +110,12 @@ func (q *Quotation) GetOrderbooks(markets []string, level float64) ([]Orderbook,
 // GetSupportedLevels는 호가 모아보기 단위 정보를 조회합니다.
 // 원화마켓(KRW)에서만 호가 모아보기 기능을 지원합니다.
 func (q *Quotation) GetSupportedLevels() ([]SupportedLevel, error) {
-	resp, err := q.Client.Get("/orderbook/supported_levels", nil)
+	return q.GetSupportedLevelsCtx(context.Background())
+}
+
+// GetSupportedLevelsCtx는 GetSupportedLevels와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetSupportedLevelsCtx(ctx context.Context) ([]SupportedLevel, error) {
+	resp, err := q.get(ctx, "/orderbook/supported_levels", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,9 @@
 package quotation
 
 import (
+	"context"
+	"net/url"
+
 	"github.com/hysuki/go-upbit/rest/client"
 )
 
@@ -19,3 +22,9 @@ func NewQuotation(client client.RestClient) *Quotation {
 		Client: client,
 	}
 }
+
+// get은 인증이 필요 없는 공개 요청으로 표시하여 GET 요청을 보냅니다.
+// 시세 조회 API는 서명하지 않으므로 API 키 없이도 호출할 수 있습니다.
+func (q *Quotation) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	return q.Client.GetCtx(client.WithPublic(ctx), path, params)
+}
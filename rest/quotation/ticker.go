@@ -1,53 +1,118 @@
 package quotation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Package quotation은 Upbit 거래소의 시세 조회 관련 API를 제공합니다.
 type Ticker struct {
-	Market             string  `json:"market"`                // 종목 구분 코드
-	TradeDate          string  `json:"trade_date"`            // 최근 거래 일자(UTC) (yyyyMMdd)
-	TradeTime          string  `json:"trade_time"`            // 최근 거래 시각(UTC) (HHmmss)
-	TradeDateKst       string  `json:"trade_date_kst"`        // 최근 거래 일자(KST) (yyyyMMdd)
-	TradeTimeKst       string  `json:"trade_time_kst"`        // 최근 거래 시각(KST) (HHmmss)
-	TradeTimestamp     int64   `json:"trade_timestamp"`       // 최근 거래 일시(UTC) (Unix Timestamp)
-	OpeningPrice       float64 `json:"opening_price"`         // 시가
-	HighPrice          float64 `json:"high_price"`            // 고가
-	LowPrice           float64 `json:"low_price"`             // 저가
-	TradePrice         float64 `json:"trade_price"`           // 종가(현재가)
-	PrevClosingPrice   float64 `json:"prev_closing_price"`    // 전일 종가
-	Change             string  `json:"change"`                // 전일 대비 (EVEN: 보합, RISE: 상승, FALL: 하락)
-	ChangePrice        float64 `json:"change_price"`          // 변화액의 절대값
-	ChangeRate         float64 `json:"change_rate"`           // 변화율의 절대값
-	SignedChangePrice  float64 `json:"signed_change_price"`   // 부호가 있는 변화액
-	SignedChangeRate   float64 `json:"signed_change_rate"`    // 부호가 있는 변화율
-	TradeVolume        float64 `json:"trade_volume"`          // 가장 최근 거래량
-	AccTradePrice      float64 `json:"acc_trade_price"`       // 누적 거래대금(UTC 0시 기준)
-	AccTradePrice24h   float64 `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
-	AccTradeVolume     float64 `json:"acc_trade_volume"`      // 누적 거래량(UTC 0시 기준)
-	AccTradeVolume24h  float64 `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
-	Highest52WeekPrice float64 `json:"highest_52_week_price"` // 52주 신고가
-	Highest52WeekDate  string  `json:"highest_52_week_date"`  // 52주 신고가 달성일 (yyyy-MM-dd)
-	Lowest52WeekPrice  float64 `json:"lowest_52_week_price"`  // 52주 신저가
-	Lowest52WeekDate   string  `json:"lowest_52_week_date"`   // 52주 신저가 달성일 (yyyy-MM-dd)
-	Timestamp          int64   `json:"timestamp"`             // 타임스탬프
+	Market             string          `json:"market"`                // 종목 구분 코드
+	TradeDate          string          `json:"trade_date"`            // 최근 거래 일자(UTC) (yyyyMMdd)
+	TradeTime          string          `json:"trade_time"`            // 최근 거래 시각(UTC) (HHmmss)
+	TradeDateKst       string          `json:"trade_date_kst"`        // 최근 거래 일자(KST) (yyyyMMdd)
+	TradeTimeKst       string          `json:"trade_time_kst"`        // 최근 거래 시각(KST) (HHmmss)
+	TradeTimestamp     time.Time       `json:"trade_timestamp"`       // 최근 거래 일시 (UTC)
+	OpeningPrice       decimal.Decimal `json:"opening_price"`         // 시가
+	HighPrice          decimal.Decimal `json:"high_price"`            // 고가
+	LowPrice           decimal.Decimal `json:"low_price"`             // 저가
+	TradePrice         decimal.Decimal `json:"trade_price"`           // 종가(현재가)
+	PrevClosingPrice   decimal.Decimal `json:"prev_closing_price"`    // 전일 종가
+	Change             string          `json:"change"`                // 전일 대비 (EVEN: 보합, RISE: 상승, FALL: 하락)
+	ChangePrice        decimal.Decimal `json:"change_price"`          // 변화액의 절대값
+	ChangeRate         decimal.Decimal `json:"change_rate"`           // 변화율의 절대값
+	SignedChangePrice  decimal.Decimal `json:"signed_change_price"`   // 부호가 있는 변화액
+	SignedChangeRate   decimal.Decimal `json:"signed_change_rate"`    // 부호가 있는 변화율
+	TradeVolume        decimal.Decimal `json:"trade_volume"`          // 가장 최근 거래량
+	AccTradePrice      decimal.Decimal `json:"acc_trade_price"`       // 누적 거래대금(UTC 0시 기준)
+	AccTradePrice24h   decimal.Decimal `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
+	AccTradeVolume     decimal.Decimal `json:"acc_trade_volume"`      // 누적 거래량(UTC 0시 기준)
+	AccTradeVolume24h  decimal.Decimal `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
+	Highest52WeekPrice decimal.Decimal `json:"highest_52_week_price"` // 52주 신고가
+	Highest52WeekDate  time.Time       `json:"highest_52_week_date"`  // 52주 신고가 달성일 (KST 0시)
+	Lowest52WeekPrice  decimal.Decimal `json:"lowest_52_week_price"`  // 52주 신저가
+	Lowest52WeekDate   time.Time       `json:"lowest_52_week_date"`   // 52주 신저가 달성일 (KST 0시)
+	Timestamp          time.Time       `json:"timestamp"`             // 타임스탬프 (UTC)
+}
+
+// upbitTicker는 Ticker의 시각 필드를 API 응답 형식으로 주고받기 위한 구조체입니다.
+type upbitTicker struct {
+	*tickerAlias
+	TradeTimestamp    int64  `json:"trade_timestamp"`
+	Highest52WeekDate string `json:"highest_52_week_date"`
+	Lowest52WeekDate  string `json:"lowest_52_week_date"`
+	Timestamp         int64  `json:"timestamp"`
+}
+
+// tickerAlias는 Ticker의 JSON 메서드가 재귀 호출되지 않도록 하는 별칭입니다.
+type tickerAlias Ticker
+
+// UnmarshalJSON은 밀리초 단위 타임스탬프를 UTC 시각으로,
+// 52주 신고가/신저가 달성일을 KST 기준 날짜로 변환합니다.
+func (t *Ticker) UnmarshalJSON(data []byte) error {
+	aux := upbitTicker{tickerAlias: (*tickerAlias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if t.Highest52WeekDate, err = parseTime(time.DateOnly, aux.Highest52WeekDate, KST); err != nil {
+		return err
+	}
+	if t.Lowest52WeekDate, err = parseTime(time.DateOnly, aux.Lowest52WeekDate, KST); err != nil {
+		return err
+	}
+	t.TradeTimestamp = fromUnixMilli(aux.TradeTimestamp)
+	t.Timestamp = fromUnixMilli(aux.Timestamp)
+	return nil
+}
+
+// MarshalJSON은 시각 필드를 API 응답과 같은 형식으로 인코딩합니다.
+func (t Ticker) MarshalJSON() ([]byte, error) {
+	return json.Marshal(upbitTicker{
+		tickerAlias:       (*tickerAlias)(&t),
+		TradeTimestamp:    toUnixMilli(t.TradeTimestamp),
+		Highest52WeekDate: formatTime(time.DateOnly, t.Highest52WeekDate, KST),
+		Lowest52WeekDate:  formatTime(time.DateOnly, t.Lowest52WeekDate, KST),
+		Timestamp:         toUnixMilli(t.Timestamp),
+	})
+}
+
+// In은 TradeTimestamp와 Timestamp를 loc 시간대로 변환한 현재가 정보를 반환합니다.
+// loc이 nil이면 UTC를 사용합니다.
+func (t Ticker) In(loc *time.Location) Ticker {
+	// loc이 nil인 경우 UTC를 사용
+	if loc == nil {
+		loc = time.UTC
+	}
+	t.TradeTimestamp = inLocation(t.TradeTimestamp, loc)
+	t.Timestamp = inLocation(t.Timestamp, loc)
+	return t
 }
 
 // GetTicker는 요청 당시 종목의 스냅샷을 조회합니다.
 // markets는 조회할 마켓 코드 목록입니다.
 func (q *Quotation) GetTicker(markets []string) ([]Ticker, error) {
+	return q.GetTickerCtx(context.Background(), markets)
+}
+
+// GetTickerCtx는 GetTicker와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetTickerCtx(ctx context.Context, markets []string) ([]Ticker, error) {
 	if len(markets) == 0 {
 		return nil, errors.New("markets is required")
 	}
 
-	params := map[string]string{
-		"markets": strings.Join(markets, ","),
+	params := url.Values{
+		"markets": {strings.Join(markets, ",")},
 	}
 
-	resp, err := q.Client.Get("/ticker", params)
+	resp, err := q.get(ctx, "/ticker", params)
 	if err != nil {
 		return nil, err
 	}
@@ -63,12 +128,17 @@ func (q *Quotation) GetTicker(markets []string) ([]Ticker, error) {
 // GetTickersByQuote는 마켓 단위 종목들의 스냅샷을 조회합니다.
 // quoteCurrencies는 기준 화폐 목록입니다. 미지정 시 모든 종목을 조회합니다.
 func (q *Quotation) GetTickersByQuote(quoteCurrencies []string) ([]Ticker, error) {
-	params := make(map[string]string)
+	return q.GetTickersByQuoteCtx(context.Background(), quoteCurrencies)
+}
+
+// GetTickersByQuoteCtx는 GetTickersByQuote와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetTickersByQuoteCtx(ctx context.Context, quoteCurrencies []string) ([]Ticker, error) {
+	params := make(url.Values)
 	if len(quoteCurrencies) > 0 {
-		params["quote_currencies"] = strings.Join(quoteCurrencies, ",")
+		params.Set("quote_currencies", strings.Join(quoteCurrencies, ","))
 	}
 
-	resp, err := q.Client.Get("/ticker/all", params)
+	resp, err := q.get(ctx, "/ticker/all", params)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,57 @@
+package quotation
+
+import "time"
+
+// KST는 한국 표준시(UTC+9) 시간대입니다.
+// 캔들의 KST 기준 시각과 52주 신고가/신저가 달성일을 해석하는 데 사용합니다.
+var KST = time.FixedZone("KST", 9*60*60)
+
+// 시세 조회 API 응답의 시각 형식입니다.
+const (
+	candleTimeLayout = "2006-01-02T15:04:05" // 캔들 기준 시각 (시간대 없음)
+	tradeTimeLayout  = "2006-01-02 15:04:05" // 체결 일자와 시각을 합친 형식
+)
+
+// fromUnixMilli는 밀리초 단위 타임스탬프를 UTC 시각으로 변환합니다.
+// 0이면 영값을 반환합니다.
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+// toUnixMilli는 시각을 밀리초 단위 타임스탬프로 변환합니다.
+// 영값이면 0을 반환합니다.
+func toUnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// parseTime은 시간대가 없는 시각 문자열을 loc 기준으로 해석합니다.
+// 빈 문자열이면 영값을 반환합니다.
+func parseTime(layout, value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// formatTime은 시각을 loc 기준의 layout 형식 문자열로 변환합니다.
+// 영값이면 빈 문자열을 반환합니다.
+func formatTime(layout string, t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(layout)
+}
+
+// inLocation은 t를 loc 시간대로 변환합니다. 영값은 그대로 반환합니다.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.In(loc)
+}
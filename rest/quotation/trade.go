@@ -1,59 +1,119 @@
 package quotation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // Package quotation은 Upbit 거래소의 시세 조회 관련 API를 제공합니다.
 
 // Trade는 체결 내역 정보를 나타냅니다.
 type Trade struct {
-	Market           string  `json:"market"`             // 종목 코드
-	TradeDate        string  `json:"trade_date_utc"`     // 체결 일자(UTC 기준)
-	TradeTime        string  `json:"trade_time_utc"`     // 체결 시각(UTC 기준)
-	Timestamp        int64   `json:"timestamp"`          // 체결 타임스탬프
-	TradePrice       float64 `json:"trade_price"`        // 체결 가격
-	TradeVolume      float64 `json:"trade_volume"`       // 체결량
-	PrevClosingPrice float64 `json:"prev_closing_price"` // 전일 종가
-	ChangePrice      float64 `json:"change_price"`       // 변화량
-	AskBid           string  `json:"ask_bid"`            // 매도/매수
-	SequentialID     int64   `json:"sequential_id"`      // 체결 번호(Unique)
+	Market           string          `json:"market"`             // 종목 코드
+	TradeDate        string          `json:"trade_date_utc"`     // 체결 일자(UTC 기준) (yyyy-MM-dd)
+	TradeTime        string          `json:"trade_time_utc"`     // 체결 시각(UTC 기준) (HH:mm:ss)
+	TradeDateTime    time.Time       `json:"-"`                  // TradeDate와 TradeTime을 합친 체결 시각 (UTC)
+	Timestamp        time.Time       `json:"timestamp"`          // 체결 타임스탬프 (UTC)
+	TradePrice       decimal.Decimal `json:"trade_price"`        // 체결 가격
+	TradeVolume      decimal.Decimal `json:"trade_volume"`       // 체결량
+	PrevClosingPrice decimal.Decimal `json:"prev_closing_price"` // 전일 종가
+	ChangePrice      decimal.Decimal `json:"change_price"`       // 변화량
+	AskBid           string          `json:"ask_bid"`            // 매도/매수
+	SequentialID     int64           `json:"sequential_id"`      // 체결 번호(Unique)
+}
+
+// upbitTrade는 Trade의 시각 필드를 API 응답 형식으로 주고받기 위한 구조체입니다.
+type upbitTrade struct {
+	*tradeAlias
+	Timestamp int64 `json:"timestamp"`
+}
+
+// tradeAlias는 Trade의 JSON 메서드가 재귀 호출되지 않도록 하는 별칭입니다.
+type tradeAlias Trade
+
+// UnmarshalJSON은 UTC 기준 체결 일자와 시각을 합쳐 TradeDateTime으로,
+// 밀리초 단위 타임스탬프를 UTC 시각으로 변환합니다.
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	aux := upbitTrade{tradeAlias: (*tradeAlias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if t.TradeDate != "" {
+		if t.TradeDateTime, err = parseTime(tradeTimeLayout, t.TradeDate+" "+t.TradeTime, time.UTC); err != nil {
+			return err
+		}
+	}
+	t.Timestamp = fromUnixMilli(aux.Timestamp)
+	return nil
+}
+
+// MarshalJSON은 시각 필드를 API 응답과 같은 형식으로 인코딩합니다.
+// 체결 일자와 시각은 TradeDate, TradeTime 문자열을 그대로 사용합니다.
+func (t Trade) MarshalJSON() ([]byte, error) {
+	aux := upbitTrade{
+		tradeAlias: (*tradeAlias)(&t),
+		Timestamp:  toUnixMilli(t.Timestamp),
+	}
+	return json.Marshal(aux)
+}
+
+// In은 TradeDateTime과 Timestamp를 loc 시간대로 변환한 체결 내역을 반환합니다.
+// TradeDate, TradeTime 문자열은 UTC 기준 그대로 유지됩니다. loc이 nil이면 UTC를 사용합니다.
+func (t Trade) In(loc *time.Location) Trade {
+	// loc이 nil인 경우 UTC를 사용
+	if loc == nil {
+		loc = time.UTC
+	}
+	t.TradeDateTime = inLocation(t.TradeDateTime, loc)
+	t.Timestamp = inLocation(t.Timestamp, loc)
+	return t
 }
 
 // GetTrades는 최근 체결 내역을 조회합니다.
 // market은 마켓 코드, to는 마지막 체결 시각, count는 체결 개수입니다.
 // cursor는 페이지네이션 커서, daysAgo는 최근 체결 날짜 기준 7일 이내의 이전 데이터 조회를 위한 파라미터입니다.
 func (q *Quotation) GetTrades(market string, to string, count int, cursor string, daysAgo int) ([]Trade, error) {
+	return q.GetTradesCtx(context.Background(), market, to, count, cursor, daysAgo)
+}
+
+// GetTradesCtx는 GetTrades와 같지만 요청에 ctx를 사용합니다.
+func (q *Quotation) GetTradesCtx(ctx context.Context, market string, to string, count int, cursor string, daysAgo int) ([]Trade, error) {
 	if market == "" {
 		return nil, errors.New("market is required")
 	}
 
-	params := map[string]string{
-		"market": market,
+	params := url.Values{
+		"market": {market},
 	}
 
 	if to != "" {
-		params["to"] = to
+		params.Set("to", to)
 	}
 	if count > 0 {
 		if count > 500 {
 			count = 500 // 최대 500개로 제한
 		}
-		params["count"] = fmt.Sprintf("%d", count)
+		params.Set("count", fmt.Sprintf("%d", count))
 	}
 	if cursor != "" {
-		params["cursor"] = cursor
+		params.Set("cursor", cursor)
 	}
 	if daysAgo > 0 {
 		if daysAgo > 7 {
 			return nil, errors.New("days_ago cannot exceed 7")
 		}
-		params["days_ago"] = fmt.Sprintf("%d", daysAgo)
+		params.Set("days_ago", fmt.Sprintf("%d", daysAgo))
 	}
 
-	resp, err := q.Client.Get("/trades/ticks", params)
+	resp, err := q.get(ctx, "/trades/ticks", params)
 	if err != nil {
 		return nil, err
 	}
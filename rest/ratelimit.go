@@ -0,0 +1,308 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upbit API의 요청 수 제한 그룹을 정의하는 상수들입니다.
+const (
+	GroupDefault        = "default"          // 주문 외 거래소 API
+	GroupOrder          = "order"            // 주문 생성
+	GroupOrderTest      = "order-test"       // 주문 생성 테스트
+	GroupOrderCancelAll = "order-cancel-all" // 일괄 주문 취소
+	GroupMarket         = "market"           // 마켓 코드 조회
+	GroupCandles        = "candles"          // 캔들 조회
+	GroupTrades         = "trades"           // 체결 내역 조회
+	GroupTicker         = "ticker"           // 현재가 조회
+	GroupOrderbook      = "orderbook"        // 호가 조회
+)
+
+// RemainingReqHeader는 잔여 요청 수 정보를 담고 있는 응답 헤더 이름입니다.
+const RemainingReqHeader = "Remaining-Req"
+
+// ErrRateLimited는 요청 수 제한에 도달하여 요청을 보내지 않았을 때 반환됩니다.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitMode는 요청 수 제한에 도달했을 때의 동작 방식을 나타냅니다.
+type RateLimitMode int
+
+// 요청 수 제한 동작 방식을 정의하는 상수들입니다.
+const (
+	RateLimitBlock    RateLimitMode = iota // 제한이 풀릴 때까지 대기
+	RateLimitReject                        // 즉시 ErrRateLimited 반환
+	RateLimitDisabled                      // 제한하지 않음 (헤더 정보만 기록)
+)
+
+// defaultGroupLimits는 그룹별 초당 최대 요청 수의 기본값입니다.
+// 서버가 알려주는 잔여 요청 수가 더 크면 해당 값으로 갱신됩니다.
+var defaultGroupLimits = map[string]int{
+	GroupDefault:        30,
+	GroupOrder:          8,
+	GroupOrderTest:      8,
+	GroupOrderCancelAll: 1,
+	GroupMarket:         10,
+	GroupCandles:        10,
+	GroupTrades:         10,
+	GroupTicker:         10,
+	GroupOrderbook:      10,
+}
+
+// RemainingReq는 Remaining-Req 헤더에 담긴 잔여 요청 수 정보를 나타냅니다.
+type RemainingReq struct {
+	Group     string    // 요청 수 제한 그룹
+	Min       int       // 분당 잔여 요청 수 (헤더에 없으면 -1)
+	Sec       int       // 초당 잔여 요청 수
+	UpdatedAt time.Time // 헤더를 수신한 시각
+}
+
+// ParseRemainingReq는 "group=default; min=1799; sec=29" 형식의 헤더 값을 파싱합니다.
+func ParseRemainingReq(value string) (*RemainingReq, error) {
+	rr := &RemainingReq{Min: -1, Sec: -1}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "group":
+			rr.Group = strings.TrimSpace(val)
+		case "min":
+			n, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil {
+				return nil, fmt.Errorf("invalid min in %s header: %w", RemainingReqHeader, err)
+			}
+			rr.Min = n
+		case "sec":
+			n, err := strconv.Atoi(strings.TrimSpace(val))
+			if err != nil {
+				return nil, fmt.Errorf("invalid sec in %s header: %w", RemainingReqHeader, err)
+			}
+			rr.Sec = n
+		}
+	}
+
+	if rr.Group == "" || rr.Sec < 0 {
+		return nil, fmt.Errorf("invalid %s header: %q", RemainingReqHeader, value)
+	}
+
+	return rr, nil
+}
+
+// groupState는 요청 그룹별 제한 상태입니다.
+type groupState struct {
+	limit     int           // 초당 최대 요청 수
+	sent      []time.Time   // 최근 1초 동안 보낸 요청 시각
+	remaining *RemainingReq // 마지막으로 수신한 잔여 요청 수 정보
+}
+
+// RateLimiter는 Remaining-Req 헤더를 기반으로 그룹별 요청 수를 제한합니다.
+type RateLimiter struct {
+	mu     sync.Mutex
+	mode   RateLimitMode
+	groups map[string]*groupState // 그룹별 상태
+	routes map[string]string      // "METHOD path" → 서버가 알려준 그룹
+	now    func() time.Time
+}
+
+// NewRateLimiter는 새로운 RateLimiter를 생성합니다.
+// mode는 제한에 도달했을 때의 동작 방식입니다.
+func NewRateLimiter(mode RateLimitMode) *RateLimiter {
+	return &RateLimiter{
+		mode:   mode,
+		groups: make(map[string]*groupState),
+		routes: make(map[string]string),
+		now:    time.Now,
+	}
+}
+
+// Mode는 현재 설정된 동작 방식을 반환합니다.
+func (l *RateLimiter) Mode() RateLimitMode {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.mode
+}
+
+// SetMode는 제한에 도달했을 때의 동작 방식을 변경합니다.
+func (l *RateLimiter) SetMode(mode RateLimitMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mode = mode
+}
+
+// Group은 요청 메서드와 경로에 해당하는 요청 수 제한 그룹을 반환합니다.
+// 서버로부터 그룹을 전달받은 적이 있다면 그 값을 우선 사용합니다.
+func (l *RateLimiter) Group(method, path string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.group(method, path)
+}
+
+// group은 잠금을 획득한 상태에서 요청 그룹을 결정합니다.
+func (l *RateLimiter) group(method, path string) string {
+	if g, ok := l.routes[method+" "+path]; ok {
+		return g
+	}
+
+	switch {
+	case path == "/market/all":
+		return GroupMarket
+	case strings.HasPrefix(path, "/candles/"):
+		return GroupCandles
+	case strings.HasPrefix(path, "/trades/"):
+		return GroupTrades
+	case strings.HasPrefix(path, "/ticker"):
+		return GroupTicker
+	case strings.HasPrefix(path, "/orderbook"):
+		return GroupOrderbook
+	case method == "POST" && (path == "/orders" || path == "/orders/cancel_and_new"):
+		return GroupOrder
+	case method == "POST" && path == "/orders/test":
+		return GroupOrderTest
+	case method == "DELETE" && path == "/orders/open":
+		return GroupOrderCancelAll
+	default:
+		return GroupDefault
+	}
+}
+
+// state는 잠금을 획득한 상태에서 그룹 상태를 반환하며, 없으면 생성합니다.
+func (l *RateLimiter) state(group string) *groupState {
+	s, ok := l.groups[group]
+	if !ok {
+		limit, ok := defaultGroupLimits[group]
+		if !ok {
+			limit = defaultGroupLimits[GroupDefault]
+		}
+		s = &groupState{limit: limit}
+		l.groups[group] = s
+	}
+	return s
+}
+
+// reserve는 요청 하나를 보낼 수 있으면 기록하고 0을 반환합니다.
+// 보낼 수 없으면 다시 시도할 때까지 기다려야 하는 시간을 반환합니다.
+func (l *RateLimiter) reserve(group string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	s := l.state(group)
+
+	// 1초가 지난 요청 기록 제거
+	i := 0
+	for i < len(s.sent) && now.Sub(s.sent[i]) >= time.Second {
+		i++
+	}
+	s.sent = s.sent[i:]
+
+	if l.mode != RateLimitDisabled {
+		if rr := s.remaining; rr != nil {
+			// 서버가 알려준 잔여 요청 수가 소진된 경우 다음 구간까지 대기
+			if rr.Min == 0 {
+				if reset := rr.UpdatedAt.Add(time.Minute); now.Before(reset) {
+					return reset.Sub(now)
+				}
+			}
+			if rr.Sec == 0 {
+				if reset := rr.UpdatedAt.Add(time.Second); now.Before(reset) {
+					return reset.Sub(now)
+				}
+			}
+		}
+		if len(s.sent) >= s.limit {
+			return s.sent[0].Add(time.Second).Sub(now)
+		}
+	}
+
+	s.sent = append(s.sent, now)
+	return 0
+}
+
+// Wait는 method와 path에 해당하는 그룹의 요청 수 제한을 확인합니다.
+// RateLimitBlock 모드에서는 제한이 풀리거나 ctx가 종료될 때까지 대기하고,
+// RateLimitReject 모드에서는 제한에 도달한 경우 ErrRateLimited를 반환합니다.
+func (l *RateLimiter) Wait(ctx context.Context, method, path string) error {
+	group := l.Group(method, path)
+
+	for {
+		wait := l.reserve(group)
+		if wait <= 0 {
+			return nil
+		}
+
+		if l.Mode() == RateLimitReject {
+			return fmt.Errorf("%w: group=%s, retry after %v", ErrRateLimited, group, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Update는 응답의 Remaining-Req 헤더 값으로 그룹 상태를 갱신합니다.
+// 헤더가 비어있거나 형식이 잘못된 경우 무시합니다.
+func (l *RateLimiter) Update(method, path, header string) *RemainingReq {
+	if header == "" {
+		return nil
+	}
+
+	rr, err := ParseRemainingReq(header)
+	if err != nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rr.UpdatedAt = l.now()
+	l.routes[method+" "+path] = rr.Group
+
+	s := l.state(rr.Group)
+	s.remaining = rr
+	// 잔여 요청 수가 알고 있는 한도보다 많다면 한도를 늘립니다.
+	if rr.Sec+1 > s.limit {
+		s.limit = rr.Sec + 1
+	}
+
+	return rr
+}
+
+// Remaining은 그룹의 마지막 잔여 요청 수 정보를 반환합니다.
+// 아직 응답을 받지 못한 그룹이면 false를 반환합니다.
+func (l *RateLimiter) Remaining(group string) (RemainingReq, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.groups[group]
+	if !ok || s.remaining == nil {
+		return RemainingReq{}, false
+	}
+	return *s.remaining, true
+}
+
+// Snapshot은 모든 그룹의 마지막 잔여 요청 수 정보를 반환합니다.
+func (l *RateLimiter) Snapshot() map[string]RemainingReq {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := make(map[string]RemainingReq, len(l.groups))
+	for group, s := range l.groups {
+		if s.remaining != nil {
+			result[group] = *s.remaining
+		}
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestRateLimiter는 시각을 직접 조정할 수 있는 RateLimiter를 생성합니다.
+func newTestRateLimiter(mode RateLimitMode) (*RateLimiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(mode)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestParseRemainingReq(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    RemainingReq
+		wantErr bool
+	}{
+		{name: "전체", header: "group=default; min=1799; sec=29", want: RemainingReq{Group: "default", Min: 1799, Sec: 29}},
+		{name: "min 없음", header: "group=order; sec=7", want: RemainingReq{Group: "order", Min: -1, Sec: 7}},
+		{name: "공백 없음", header: "group=market;min=0;sec=0", want: RemainingReq{Group: "market", Min: 0, Sec: 0}},
+		{name: "group 없음", header: "min=1; sec=1", wantErr: true},
+		{name: "sec 없음", header: "group=default; min=1", wantErr: true},
+		{name: "숫자가 아닌 sec", header: "group=default; sec=x", wantErr: true},
+		{name: "숫자가 아닌 min", header: "group=default; min=x; sec=1", wantErr: true},
+		{name: "빈 값", header: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRemainingReq(tt.header)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRemainingReq(%q) = %+v, want error", tt.header, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRemainingReq(%q) error: %v", tt.header, err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseRemainingReq(%q) = %+v, want %+v", tt.header, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterGroup(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{method: "GET", path: "/market/all", want: GroupMarket},
+		{method: "GET", path: "/candles/minutes/1", want: GroupCandles},
+		{method: "GET", path: "/trades/ticks", want: GroupTrades},
+		{method: "GET", path: "/ticker", want: GroupTicker},
+		{method: "GET", path: "/orderbook", want: GroupOrderbook},
+		{method: "POST", path: "/orders", want: GroupOrder},
+		{method: "POST", path: "/orders/cancel_and_new", want: GroupOrder},
+		{method: "POST", path: "/orders/test", want: GroupOrderTest},
+		{method: "DELETE", path: "/orders/open", want: GroupOrderCancelAll},
+		{method: "GET", path: "/orders", want: GroupDefault},
+		{method: "GET", path: "/accounts", want: GroupDefault},
+	}
+
+	l := NewRateLimiter(RateLimitBlock)
+	for _, tt := range tests {
+		if got := l.Group(tt.method, tt.path); got != tt.want {
+			t.Errorf("Group(%s, %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+
+	// 서버가 알려준 그룹이 경로 규칙보다 우선합니다.
+	l.Update("GET", "/accounts", "group=custom; sec=5")
+	if got := l.Group("GET", "/accounts"); got != "custom" {
+		t.Errorf("Group after Update = %s, want custom", got)
+	}
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     RateLimitMode
+		group    string
+		header   string        // 예약 전에 Update로 전달할 헤더 (빈 값이면 생략)
+		advance  time.Duration // Update 후 예약 전까지 지난 시간
+		requests int           // 예약 전에 보낸 요청 수
+		want     time.Duration
+	}{
+		{name: "한도 미만", mode: RateLimitBlock, group: GroupOrder, requests: 7, want: 0},
+		{name: "한도 도달", mode: RateLimitBlock, group: GroupOrder, requests: 8, want: time.Second},
+		{name: "알 수 없는 그룹은 기본 한도", mode: RateLimitBlock, group: "unknown", requests: 30, want: time.Second},
+		{name: "제한 없음 모드", mode: RateLimitDisabled, group: GroupOrderCancelAll, requests: 5, want: 0},
+		{name: "초당 잔여 요청 소진", mode: RateLimitBlock, group: GroupDefault, header: "group=default; min=100; sec=0", advance: 300 * time.Millisecond, want: 700 * time.Millisecond},
+		{name: "초당 구간 지난 뒤", mode: RateLimitBlock, group: GroupDefault, header: "group=default; min=100; sec=0", advance: time.Second, want: 0},
+		{name: "분당 잔여 요청 소진", mode: RateLimitBlock, group: GroupDefault, header: "group=default; min=0; sec=10", advance: 10 * time.Second, want: 50 * time.Second},
+		{name: "잔여 요청 소진도 제한 없음 모드에서는 무시", mode: RateLimitDisabled, group: GroupDefault, header: "group=default; min=0; sec=0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, now := newTestRateLimiter(tt.mode)
+			if tt.header != "" {
+				l.Update("GET", "/accounts", tt.header)
+				*now = now.Add(tt.advance)
+			}
+			for i := 0; i < tt.requests; i++ {
+				if wait := l.reserve(tt.group); wait != 0 {
+					t.Fatalf("request %d: reserve = %v, want 0", i+1, wait)
+				}
+			}
+			if got := l.reserve(tt.group); got != tt.want {
+				t.Errorf("reserve = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterReserveSlidingWindow(t *testing.T) {
+	l, now := newTestRateLimiter(RateLimitBlock)
+
+	for i := 0; i < 8; i++ {
+		l.reserve(GroupOrder)
+		*now = now.Add(100 * time.Millisecond)
+	}
+	// 첫 요청은 800ms 전에 보냈으므로 200ms 뒤에 보낼 수 있습니다.
+	if got := l.reserve(GroupOrder); got != 200*time.Millisecond {
+		t.Fatalf("reserve = %v, want 200ms", got)
+	}
+
+	*now = now.Add(200 * time.Millisecond)
+	if got := l.reserve(GroupOrder); got != 0 {
+		t.Fatalf("reserve after window = %v, want 0", got)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantNil   bool
+		wantLimit int
+	}{
+		{name: "빈 헤더", header: "", wantNil: true, wantLimit: 8},
+		{name: "잘못된 헤더", header: "garbage", wantNil: true, wantLimit: 8},
+		{name: "한도보다 작은 잔여 요청", header: "group=order; min=100; sec=3", wantLimit: 8},
+		{name: "한도보다 큰 잔여 요청", header: "group=order; min=100; sec=19", wantLimit: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, now := newTestRateLimiter(RateLimitBlock)
+			rr := l.Update("POST", "/orders", tt.header)
+			if tt.wantNil {
+				if rr != nil {
+					t.Fatalf("Update = %+v, want nil", rr)
+				}
+				if _, ok := l.Remaining(GroupOrder); ok {
+					t.Error("Remaining reported a value for an ignored header")
+				}
+			} else {
+				if rr == nil {
+					t.Fatal("Update = nil")
+				}
+				if !rr.UpdatedAt.Equal(*now) {
+					t.Errorf("UpdatedAt = %v, want %v", rr.UpdatedAt, *now)
+				}
+				got, ok := l.Remaining(rr.Group)
+				if !ok || got != *rr {
+					t.Errorf("Remaining = %+v, %v, want %+v", got, ok, *rr)
+				}
+				if _, ok := l.Snapshot()[rr.Group]; !ok {
+					t.Errorf("Snapshot has no %s group", rr.Group)
+				}
+			}
+
+			l.mu.Lock()
+			limit := l.state(GroupOrder).limit
+			l.mu.Unlock()
+			if limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitReject(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimitReject)
+	l.Update("DELETE", "/orders/open", "group=order-cancel-all; sec=0")
+
+	err := l.Wait(context.Background(), "DELETE", "/orders/open")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Wait = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRateLimiterWaitContextCanceled(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimitBlock)
+	l.Update("DELETE", "/orders/open", "group=order-cancel-all; min=0; sec=0")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, "DELETE", "/orders/open"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+}
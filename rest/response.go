@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// requestIDHeaders는 요청 ID로 사용할 응답 헤더 이름입니다. 앞에 있는 헤더를 우선합니다.
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// Response는 REST API 응답의 메타데이터입니다.
+// WithResponseCapture로 지정한 컨텍스트로 Exchange, Quotation 메서드를 호출하면 채워집니다.
+type Response struct {
+	StatusCode   int           // HTTP 상태 코드
+	Header       http.Header   // 응답 헤더
+	RemainingReq *RemainingReq // Remaining-Req 헤더의 잔여 요청 수 정보 (헤더가 없으면 nil)
+	Date         time.Time     // 서버의 Date 헤더 (없으면 영값)
+	RequestID    string        // 요청 ID 헤더 값 (없으면 빈 문자열)
+	Attempts     int           // 재시도를 포함한 요청 시도 횟수
+	Latency      time.Duration // 마지막 시도의 요청 전송부터 응답 헤더 수신까지 걸린 시간
+	ReceivedAt   time.Time     // 응답 헤더를 수신한 로컬 시각
+}
+
+// ClockOffset은 서버 시각과 로컬 시각의 차이를 추정합니다.
+// 양수이면 서버 시각이 로컬 시각보다 앞서 있습니다. Date 헤더가 없으면 0을 반환합니다.
+// Date 헤더는 초 단위이므로 1초 미만의 오차가 있을 수 있습니다.
+func (r *Response) ClockOffset() time.Duration {
+	if r.Date.IsZero() {
+		return 0
+	}
+	// 서버는 요청과 응답의 중간 시점에 Date를 기록했다고 가정합니다.
+	return r.Date.Sub(r.ReceivedAt.Add(-r.Latency / 2))
+}
+
+// responseCaptureKey는 응답 메타데이터를 기록할 Response를 컨텍스트에 저장할 때 사용하는 키입니다.
+type responseCaptureKey struct{}
+
+// WithResponseCapture는 요청의 응답 메타데이터를 resp에 기록하도록 하는 컨텍스트를 반환합니다.
+// API 에러가 발생한 경우에도 응답을 받았다면 기록되며, 네트워크 에러로 응답을 받지 못하면
+// 변경되지 않습니다. 하나의 resp를 여러 요청에 동시에 사용하면 안 됩니다.
+//
+//	var meta rest.Response
+//	accounts, err := client.GetExchange().GetAccountsCtx(rest.WithResponseCapture(ctx, &meta))
+//	log.Printf("status=%d remaining=%+v request_id=%s", meta.StatusCode, meta.RemainingReq, meta.RequestID)
+func WithResponseCapture(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, resp)
+}
+
+// capturedResponse는 ctx에 지정된 Response를 반환합니다. 없으면 nil을 반환합니다.
+func capturedResponse(ctx context.Context) *Response {
+	resp, _ := ctx.Value(responseCaptureKey{}).(*Response)
+	return resp
+}
+
+// captureResponse는 ctx에 Response가 지정되어 있으면 resp의 메타데이터를 기록합니다.
+func captureResponse(ctx context.Context, resp *http.Response, rr *RemainingReq, attempts int, sentAt time.Time) {
+	meta := capturedResponse(ctx)
+	if meta == nil {
+		return
+	}
+	now := time.Now()
+
+	*meta = Response{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		RemainingReq: rr,
+		Attempts:     attempts,
+		Latency:      now.Sub(sentAt),
+		ReceivedAt:   now,
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		meta.Date = date
+	}
+	for _, name := range requestIDHeaders {
+		if id := resp.Header.Get(name); id != "" {
+			meta.RequestID = id
+			break
+		}
+	}
+}
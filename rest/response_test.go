@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCapture(t *testing.T) {
+	// 서버 시각은 로컬 시각보다 1시간 앞서 있습니다.
+	serverTime := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set(RemainingReqHeader, "group=default; min=1799; sec=29")
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv, WithRetryPolicy(fastRetry(3)))
+
+	var meta Response
+	if _, err := c.GetCtx(WithResponseCapture(context.Background(), &meta), "/accounts", nil); err != nil {
+		t.Fatalf("GetCtx error: %v", err)
+	}
+
+	// 재시도한 경우 마지막 응답과 전체 시도 횟수가 기록됩니다.
+	if meta.StatusCode != http.StatusOK || meta.Attempts != 2 {
+		t.Errorf("StatusCode = %d, Attempts = %d, want 200 and 2", meta.StatusCode, meta.Attempts)
+	}
+	if meta.RequestID != "req-1" || meta.Header.Get("X-Request-Id") != "req-1" {
+		t.Errorf("RequestID = %q, want req-1", meta.RequestID)
+	}
+	if rr := meta.RemainingReq; rr == nil || rr.Group != GroupDefault || rr.Sec != 29 {
+		t.Errorf("RemainingReq = %+v, want default group with sec=29", rr)
+	}
+	if !meta.Date.Equal(serverTime) {
+		t.Errorf("Date = %v, want %v", meta.Date, serverTime)
+	}
+	if meta.Latency < 0 || meta.ReceivedAt.IsZero() {
+		t.Errorf("Latency = %v, ReceivedAt = %v", meta.Latency, meta.ReceivedAt)
+	}
+	if offset := meta.ClockOffset(); offset < time.Hour-2*time.Second || offset > time.Hour+time.Second {
+		t.Errorf("ClockOffset = %v, want about 1h", offset)
+	}
+}
+
+func TestResponseCaptureError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cf-Ray", "ray-1")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"name":"validation_error","message":"잘못된 요청입니다."}}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(srv)
+
+	// API 에러 응답도 기록됩니다.
+	var meta Response
+	if _, err := c.GetCtx(WithResponseCapture(context.Background(), &meta), "/accounts", nil); err == nil {
+		t.Fatal("GetCtx succeeded")
+	}
+	if meta.StatusCode != http.StatusBadRequest || meta.Attempts != 1 || meta.RequestID != "ray-1" {
+		t.Errorf("meta = %+v, want status 400, 1 attempt, request id ray-1", meta)
+	}
+
+	// 응답을 받지 못하면 변경되지 않습니다.
+	srv.Close()
+	meta = Response{StatusCode: -1}
+	if _, err := c.GetCtx(WithResponseCapture(context.Background(), &meta), "/accounts", nil); err == nil {
+		t.Fatal("GetCtx to a closed server succeeded")
+	}
+	if meta.StatusCode != -1 {
+		t.Errorf("meta = %+v, want it unchanged", meta)
+	}
+}
+
+func TestResponseClockOffset(t *testing.T) {
+	received := time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		resp Response
+		want time.Duration
+	}{
+		{name: "Date 없음", resp: Response{ReceivedAt: received, Latency: time.Second}, want: 0},
+		{name: "서버가 앞섬", resp: Response{Date: received.Add(5 * time.Second), ReceivedAt: received, Latency: 2 * time.Second}, want: 6 * time.Second},
+		{name: "서버가 늦음", resp: Response{Date: received.Add(-5 * time.Second), ReceivedAt: received}, want: -5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := tt.resp.ClockOffset(); got != tt.want {
+			t.Errorf("%s: ClockOffset = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+
+	restclient "github.com/hysuki/go-upbit/rest/client"
+)
+
+// RetryPolicy는 REST 요청의 재시도 정책을 나타냅니다.
+//
+// GET 요청은 항상 재시도 대상이며, POST와 DELETE 요청은 client.WithIdempotent로
+// 표시된 경우에만 재시도합니다. 예를 들어 Exchange.CreateOrder는 Identifier가
+// 지정된 경우에만 재시도되므로 같은 주문이 두 번 체결되지 않습니다.
+type RetryPolicy struct {
+	MaxAttempts     int           // 첫 요청을 포함한 최대 시도 횟수 (1 이하이면 재시도하지 않음)
+	BaseDelay       time.Duration // 첫 재시도 전 대기 시간의 기준값
+	MaxDelay        time.Duration // 재시도 간 최대 대기 시간
+	RetryableStatus map[int]bool  // 재시도할 HTTP 상태 코드
+}
+
+// NoRetry는 재시도하지 않는 정책입니다. REST 클라이언트의 기본값입니다.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// DefaultRetryPolicy는 일반적인 용도의 재시도 정책을 반환합니다.
+// 최대 3회 시도하며 429와 5xx 응답, 네트워크 오류를 재시도합니다.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    3 * time.Second,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+	}
+}
+
+// canRetry는 ctx와 method로 보아 요청을 다시 보내도 안전한지 확인합니다.
+func (p RetryPolicy) canRetry(ctx context.Context, method string) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	return method == http.MethodGet || restclient.IsIdempotent(ctx)
+}
+
+// backoff는 attempt번째 재시도 전 대기 시간을 계산합니다.
+// 지수적으로 증가하는 상한 안에서 무작위 값을 선택합니다 (full jitter).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	if attempt > 30 {
+		attempt = 30
+	}
+
+	ceiling := p.BaseDelay << uint(attempt)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// sleep은 d만큼 대기합니다. ctx가 먼저 종료되면 ctx의 에러를 반환합니다.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
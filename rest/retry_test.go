@@ -0,0 +1,206 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hysuki/go-upbit/auth"
+	restclient "github.com/hysuki/go-upbit/rest/client"
+	"github.com/hysuki/go-upbit/rest/exchange"
+)
+
+// newStatusServer는 statuses의 상태 코드를 순서대로 한 번씩 응답하고, 그 뒤로는 200 응답을
+// 반환하는 테스트 서버와 받은 요청 수를 생성합니다.
+func newStatusServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// newTestClient는 srv로 요청을 보내고 요청 수 제한을 사용하지 않는 클라이언트를 생성합니다.
+func newTestClient(srv *httptest.Server, opts ...ClientOption) *client {
+	creds := auth.Credentials{AccessKey: "access", SecretKey: "secret"}
+	opts = append([]ClientOption{WithBaseURL(srv.URL), WithRateLimitMode(RateLimitDisabled)}, opts...)
+	return NewClient(auth.NewRestTokenGen(creds), opts...)
+}
+
+// fastRetry는 대기 없이 maxAttempts회까지 시도하는 재시도 정책을 반환합니다.
+func fastRetry(maxAttempts int) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.BaseDelay = 0
+	return policy
+}
+
+func TestRetryGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+		wantErr   int // 기대하는 에러의 상태 코드 (0이면 성공)
+	}{
+		{name: "500 후 성공", statuses: []int{500}, wantCalls: 2},
+		{name: "429와 503 후 성공", statuses: []int{429, 503}, wantCalls: 3},
+		{name: "최대 시도 횟수 초과", statuses: []int{502, 502, 502, 502}, wantCalls: 3, wantErr: 502},
+		{name: "재시도하지 않는 상태 코드", statuses: []int{400}, wantCalls: 1, wantErr: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newStatusServer(t, tt.statuses...)
+			c := newTestClient(srv, WithRetryPolicy(fastRetry(3)))
+
+			_, err := c.Get("/accounts", nil)
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if tt.wantErr == 0 {
+				if err != nil {
+					t.Errorf("Get error: %v", err)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantErr {
+				t.Errorf("Get error = %v, want status %d", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name      string
+		call      func(c *client) error
+		wantCalls int32
+	}{
+		{
+			name: "POST",
+			call: func(c *client) error {
+				_, err := c.Post("/withdraws/krw", map[string]string{"amount": "10000"})
+				return err
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "DELETE",
+			call: func(c *client) error {
+				_, err := c.Delete("/order", nil)
+				return err
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "WithIdempotent POST",
+			call: func(c *client) error {
+				_, err := c.PostCtx(restclient.WithIdempotent(context.Background()), "/orders", map[string]string{"identifier": "id"})
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "Identifier 없는 CreateOrder",
+			call: func(c *client) error {
+				_, err := c.GetExchange().CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypePrice, Price: "10000"})
+				return err
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Identifier 있는 CreateOrder",
+			call: func(c *client) error {
+				_, err := c.GetExchange().CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypePrice, Price: "10000", Identifier: "id"})
+				return err
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newStatusServer(t, http.StatusServiceUnavailable)
+			c := newTestClient(srv, WithRetryPolicy(fastRetry(3)))
+
+			err := tt.call(c)
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			// 재시도하지 않은 요청은 첫 응답의 에러를 반환합니다.
+			if tt.wantCalls == 1 && !errors.Is(err, ErrServerError) {
+				t.Errorf("error = %v, want server_error", err)
+			}
+			if tt.wantCalls > 1 && err != nil {
+				t.Errorf("error = %v after retrying", err)
+			}
+		})
+	}
+}
+
+func TestRetryDefaultNoRetry(t *testing.T) {
+	srv, calls := newStatusServer(t, http.StatusInternalServerError)
+	c := newTestClient(srv)
+
+	if _, err := c.Get("/accounts", nil); !errors.Is(err, ErrServerError) {
+		t.Errorf("Get error = %v, want server_error", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	srv, calls := newStatusServer(t, http.StatusInternalServerError, http.StatusInternalServerError)
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+	c := newTestClient(srv, WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetCtx(ctx, "/accounts", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCtx error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetCtx returned after %v, want it to stop waiting when ctx ends", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 1; attempt <= 40; attempt++ {
+		ceiling := time.Second
+		if attempt < 4 {
+			ceiling = 100 * time.Millisecond << attempt
+		}
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(attempt); d < 0 || d > ceiling {
+				t.Fatalf("backoff(%d) = %v, want within [0, %v]", attempt, d, ceiling)
+			}
+		}
+	}
+
+	if d := (RetryPolicy{}).backoff(3); d != 0 {
+		t.Errorf("backoff without BaseDelay = %v, want 0", d)
+	}
+}
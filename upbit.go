@@ -1,12 +1,16 @@
 package upbit
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/hysuki/go-upbit/auth"
 	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+	"github.com/hysuki/go-upbit/websocket"
 	"github.com/hysuki/go-upbit/websocket/private"
 	"github.com/hysuki/go-upbit/websocket/public"
 )
@@ -20,13 +24,40 @@ const (
 
 // UpbitClient는 Upbit API 클라이언트입니다.
 type UpbitClient struct {
-	credentials  auth.Credentials // API 인증 정보
-	pingInterval time.Duration    // 웹소켓 핑 전송 간격
-	PublicWS     *public.Client   // 공개 웹소켓 클라이언트
-	PrivateWS    *private.Client  // 비공개 웹소켓 클라이언트
-	RestAPI      rest.Client      // REST API 클라이언트
+	credentials     auth.Credentials         // API 인증 정보
+	credsProvider   auth.CredentialsProvider // API 인증 정보 제공자 (설정 시 credentials보다 우선)
+	signer          auth.Signer              // 토큰 서명기 (설정 시 인증 정보 대신 사용)
+	keyPool         *rest.KeyPool            // REST API 요청에 사용할 키 풀
+	pingInterval    time.Duration            // 웹소켓 핑 전송 간격
+	restOptions     []rest.ClientOption      // REST API 클라이언트 옵션
+	publicEndpoint  string                   // 공개 웹소켓 API 주소
+	privateEndpoint string                   // 비공개 웹소켓 API 주소
+	wsHTTPClient    *http.Client             // 웹소켓 연결에 사용할 HTTP 클라이언트
+	components      component                // 생성할 하위 클라이언트 (0이면 전체)
+	lazyConnect     bool                     // 웹소켓 지연 연결 여부
+	PublicWS        *public.Client           // 공개 웹소켓 클라이언트
+	PrivateWS       *private.Client          // 비공개 웹소켓 클라이언트
+	expiryWithin    time.Duration            // API 키 만료 경고 기준
+	expiryWarn      exchange.KeyExpiryFunc   // API 키 만료 경고 함수
+	dryRun          bool                     // 거래소 API 모의 실행 모드 여부
+	dryRunLog       exchange.DryRunLogFunc   // 모의 실행 모드의 요청 기록 함수
+	optionErr       error                    // 옵션 적용 중 발생한 에러
+	stopRotate      func()                   // 키 교체 알림 등록 해제 함수
+	RestAPI         rest.Client              // REST API 클라이언트
 }
 
+// component는 UpbitClient가 생성하는 하위 클라이언트를 나타냅니다.
+type component uint8
+
+// 하위 클라이언트를 정의하는 상수들입니다.
+const (
+	componentREST      component = 1 << iota // REST API 클라이언트
+	componentPublicWS                        // 공개 웹소켓 클라이언트
+	componentPrivateWS                       // 비공개 웹소켓 클라이언트
+
+	componentAll = componentREST | componentPublicWS | componentPrivateWS
+)
+
 // UpbitClientOption은 UpbitClient의 설정을 변경하는 함수 타입입니다.
 type UpbitClientOption func(*UpbitClient)
 
@@ -41,6 +72,83 @@ func WithKeys(accessKey, apiSecret string) UpbitClientOption {
 	}
 }
 
+// WithKeystore는 path의 암호화된 키 저장소에서 auth.DefaultKeystoreEntry 항목의
+// API 키를 읽어 사용하는 옵션을 반환합니다. 키 저장소를 열 수 없으면 NewUpbitClient가 에러를 반환합니다.
+// 다른 이름의 항목을 사용하려면 auth.OpenKeystore와 WithCredentialsProvider를 사용합니다.
+func WithKeystore(path, passphrase string) UpbitClientOption {
+	return func(c *UpbitClient) {
+		ks, err := auth.OpenKeystore(path, passphrase)
+		if err != nil {
+			c.optionErr = err
+			return
+		}
+		creds, err := ks.Credentials(auth.DefaultKeystoreEntry)
+		if err != nil {
+			c.optionErr = err
+			return
+		}
+		c.credentials = creds
+	}
+}
+
+// WithCredentialsProvider는 API 인증 정보를 provider에서 가져오도록 설정하는 옵션을 반환합니다.
+// 토큰을 생성할 때마다 provider를 호출하므로 키가 교체되면 REST API는 다음 요청부터 새 키를 사용합니다.
+// provider가 auth.RotationNotifier를 구현하면 키가 교체될 때 비공개 웹소켓을 새 토큰으로 다시 연결합니다.
+// WithKeys와 함께 지정하면 provider가 우선합니다.
+func WithCredentialsProvider(provider auth.CredentialsProvider) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.credsProvider = provider
+	}
+}
+
+// WithSigner는 API 토큰 서명을 signer에 맡기도록 설정하는 옵션을 반환합니다.
+// 시크릿 키를 프로세스에 두지 않고 서명 데몬 등에서 서명할 때 사용하며,
+// REST API와 비공개 웹소켓 모두 signer로 토큰을 생성합니다. WithKeys, WithCredentialsProvider보다 우선합니다.
+//
+//	client, err := upbit.NewUpbitClient(upbit.WithSigner(auth.NewUnixSocketSigner("/run/upbit-signer.sock", 0)))
+func WithSigner(signer auth.Signer) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.signer = signer
+	}
+}
+
+// WithKeyPool은 REST API 요청을 pool의 여러 키로 나누어 보내도록 설정하는 옵션을 반환합니다.
+// 키 풀은 REST API에만 사용되며, 비공개 웹소켓은 WithKeys나 WithCredentialsProvider로
+// 지정한 키를 사용합니다.
+//
+// 클라이언트의 요청 수 제한기는 키를 구분하지 않으므로 요청 수 제한을 끕니다(RateLimitDisabled).
+// 키별 잔여 요청 수는 키 풀이 추적하며, WithRESTOptions로 지정한 제한 방식이 있으면 그 설정을 따릅니다.
+func WithKeyPool(pool *rest.KeyPool) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.keyPool = pool
+		// 다른 REST API 옵션보다 먼저 적용해 WithRESTOptions의 설정이 우선하도록 합니다.
+		c.restOptions = append([]rest.ClientOption{rest.WithRateLimitMode(rest.RateLimitDisabled)}, c.restOptions...)
+	}
+}
+
+// WithKeyExpiryCheck는 클라이언트 생성 시 사용 중인 API 키의 만료 시각을 확인하여,
+// within 안에 만료되면 warn을 호출하는 옵션을 반환합니다.
+// 키 목록 조회 등 확인에 실패해도 클라이언트 생성은 계속되며, 실패 원인은 warn의 err로 전달됩니다.
+// 키 풀이나 서명기를 사용해 사용 중인 키를 알 수 없으면 계정의 모든 키를 확인합니다.
+func WithKeyExpiryCheck(within time.Duration, warn exchange.KeyExpiryFunc) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.expiryWithin = within
+		c.expiryWarn = warn
+	}
+}
+
+// WithDryRun은 거래소 API를 모의 실행 모드로 생성하는 옵션을 반환합니다.
+// 주문 생성은 주문 생성 테스트로 검증만 하고, 주문 취소와 입출금은 요청을 보내지 않습니다.
+// 보내지 않은 요청은 logf로 기록하며, logf가 nil이면 기록하지 않습니다.
+// 출력에 기록하려면 exchange.DryRunWriter(os.Stderr)를 전달합니다.
+// 생성 후에는 RestAPI.GetExchange().SetDryRun으로 모드를 바꿀 수 있습니다.
+func WithDryRun(logf exchange.DryRunLogFunc) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.dryRun = true
+		c.dryRunLog = logf
+	}
+}
+
 // WithPingInterval은 웹소켓 핑 전송 간격을 설정하는 옵션을 반환합니다.
 // interval은 핑 전송 간격입니다.
 func WithPingInterval(interval time.Duration) UpbitClientOption {
@@ -49,66 +157,172 @@ func WithPingInterval(interval time.Duration) UpbitClientOption {
 	}
 }
 
+// WithRESTBaseURL은 REST API 기본 URL을 설정하는 옵션을 반환합니다.
+// 기본값은 RestAPIEndpoint입니다.
+func WithRESTBaseURL(baseURL string) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.restOptions = append(c.restOptions, rest.WithBaseURL(baseURL))
+	}
+}
+
+// WithHTTPClient는 REST API 요청에 사용할 HTTP 클라이언트를 설정하는 옵션을 반환합니다.
+// 프록시, TLS 설정, 타임아웃 등은 httpClient의 Transport로 지정합니다.
+func WithHTTPClient(httpClient *http.Client) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.restOptions = append(c.restOptions, rest.WithHTTPClient(httpClient))
+	}
+}
+
+// WithRESTOptions는 REST API 클라이언트 옵션을 직접 지정하는 옵션을 반환합니다.
+// 요청 수 제한 방식이나 재시도 정책 등을 설정할 때 사용합니다.
+func WithRESTOptions(opts ...rest.ClientOption) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.restOptions = append(c.restOptions, opts...)
+	}
+}
+
+// WithWebsocketEndpoints는 공개/비공개 웹소켓 API 주소를 설정하는 옵션을 반환합니다.
+// 빈 문자열을 전달한 주소는 기본값을 유지합니다.
+func WithWebsocketEndpoints(publicEndpoint, privateEndpoint string) UpbitClientOption {
+	return func(c *UpbitClient) {
+		if publicEndpoint != "" {
+			c.publicEndpoint = publicEndpoint
+		}
+		if privateEndpoint != "" {
+			c.privateEndpoint = privateEndpoint
+		}
+	}
+}
+
+// WithWebsocketHTTPClient는 웹소켓 연결에 사용할 HTTP 클라이언트를 설정하는 옵션을 반환합니다.
+// 프록시, TLS 설정, 사용자 정의 다이얼러는 httpClient의 Transport로 지정합니다.
+func WithWebsocketHTTPClient(httpClient *http.Client) UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.wsHTTPClient = httpClient
+	}
+}
+
+// WithREST는 REST API 클라이언트를 생성하도록 지정하는 옵션을 반환합니다.
+// WithREST, WithPublicWS, WithPrivateWS 중 하나라도 지정하면 지정한 클라이언트만 생성되며,
+// 아무것도 지정하지 않으면 모든 클라이언트가 생성됩니다.
+func WithREST() UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.components |= componentREST
+	}
+}
+
+// WithPublicWS는 공개 웹소켓 클라이언트를 생성하도록 지정하는 옵션을 반환합니다.
+func WithPublicWS() UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.components |= componentPublicWS
+	}
+}
+
+// WithPrivateWS는 비공개 웹소켓 클라이언트를 생성하도록 지정하는 옵션을 반환합니다.
+func WithPrivateWS() UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.components |= componentPrivateWS
+	}
+}
+
+// WithLazyConnect는 웹소켓을 클라이언트 생성 시점이 아닌 첫 Subscribe 시점에
+// 연결하도록 설정하는 옵션을 반환합니다.
+func WithLazyConnect() UpbitClientOption {
+	return func(c *UpbitClient) {
+		c.lazyConnect = true
+	}
+}
+
 // GetPingInterval은 현재 설정된 웹소켓 핑 전송 간격을 반환합니다.
 func (c *UpbitClient) GetPingInterval() time.Duration {
 	return c.pingInterval
 }
 
+// IsAuthenticated는 API 인증 정보가 설정되어 있는지 확인합니다.
+// WithCredentialsProvider, WithSigner, WithKeyPool 중 하나를 지정한 경우 항상 true를 반환합니다.
+func (c *UpbitClient) IsAuthenticated() bool {
+	return c.signer != nil || c.provider() != nil || c.keyPool != nil
+}
+
 // NewUpbitClient는 새로운 Upbit API 클라이언트를 생성합니다.
-// opts로 클라이언트 설정을 지정할 수 있으며, WithKeys 옵션은 필수입니다.
+// opts로 클라이언트 설정을 지정할 수 있습니다.
+//
+// WithKeys 옵션 없이 생성하면 공개 API 전용 클라이언트가 됩니다.
+// 이 경우 시세 조회 API와 공개 웹소켓은 그대로 사용할 수 있고,
+// 거래소 API와 비공개 웹소켓은 auth.ErrNotAuthenticated를 반환합니다.
+//
+// WithREST, WithPublicWS, WithPrivateWS로 일부 클라이언트만 생성할 수 있으며,
+// 생성하지 않은 클라이언트의 필드는 nil입니다.
 func NewUpbitClient(opts ...UpbitClientOption) (client *UpbitClient, err error) {
 	client = &UpbitClient{
-		pingInterval: 30 * time.Second, // 기본값 설정
+		pingInterval:    30 * time.Second, // 기본값 설정
+		publicEndpoint:  PublicWebsocketEndpoint,
+		privateEndpoint: PrivateWebsocketEndpoint,
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
+	if client.optionErr != nil {
+		return nil, fmt.Errorf("클라이언트 설정 에러: %w", client.optionErr)
+	}
 
-	// 인증 정보 검증
-	if client.credentials == (auth.Credentials{}) {
-		return nil, fmt.Errorf("인증 정보가 필요합니다")
+	if client.components == 0 {
+		client.components = componentAll
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(3) // REST API + Public WS + Private WS
 
 	// 공통 에러 채널
 	errCh := make(chan error, 3)
 
 	// REST API 클라이언트 초기화
-	go func() {
-		defer wg.Done()
-		restTokenGen := auth.NewRestTokenGen(client.credentials)
-		client.RestAPI = rest.NewClient(restTokenGen)
-		if client.RestAPI == nil {
-			errCh <- fmt.Errorf("REST API 클라이언트 초기화 실패")
-		}
-	}()
+	if client.components&componentREST != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.RestAPI = rest.NewClient(client.restTokenGen(), client.restOptions...)
+			if client.RestAPI == nil {
+				errCh <- fmt.Errorf("REST API 클라이언트 초기화 실패")
+			}
+		}()
+	}
 
 	// Public WebSocket 클라이언트 초기화
-	go func() {
-		defer wg.Done()
-		wsTokenGen := auth.NewWebSocketTokenGen(client.credentials)
-		pub, err := public.NewClient(PublicWebsocketEndpoint, wsTokenGen, client.pingInterval)
-		if err != nil {
-			errCh <- fmt.Errorf("공개 웹소켓 클라이언트 에러: %w", err)
-			return
-		}
-		client.PublicWS = pub
-	}()
+	if client.components&componentPublicWS != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pub, err := public.NewClient(client.publicEndpoint, client.wsTokenGen(), client.pingInterval, client.websocketOptions()...)
+			if err != nil {
+				errCh <- fmt.Errorf("공개 웹소켓 클라이언트 에러: %w", err)
+				return
+			}
+			client.PublicWS = pub
+		}()
+	}
 
 	// Private WebSocket 클라이언트 초기화
-	go func() {
-		defer wg.Done()
-		wsTokenGen := auth.NewWebSocketTokenGen(client.credentials)
-		pri, err := private.NewClient(PrivateWebsocketEndpoint, wsTokenGen, client.pingInterval)
-		if err != nil {
-			errCh <- fmt.Errorf("비공개 웹소켓 클라이언트 에러: %w", err)
-			return
-		}
-		client.PrivateWS = pri
-	}()
+	if client.components&componentPrivateWS != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pri, err := private.NewClient(client.privateEndpoint, client.wsTokenGen(), client.pingInterval, client.websocketOptions()...)
+			if err != nil {
+				errCh <- fmt.Errorf("비공개 웹소켓 클라이언트 에러: %w", err)
+				return
+			}
+			client.PrivateWS = pri
+
+			// 키가 교체되면 비공개 웹소켓을 새 토큰으로 다시 연결합니다.
+			// 재연결 에러는 비공개 웹소켓의 에러 채널로 전달되며, 등록은 Close에서 해제됩니다.
+			if notifier, ok := client.credsProvider.(auth.RotationNotifier); ok {
+				client.stopRotate = notifier.OnRotate(func(auth.Credentials) {
+					go pri.Refresh()
+				})
+			}
+		}()
+	}
 
 	wg.Wait()
 	close(errCh)
@@ -120,14 +334,103 @@ func NewUpbitClient(opts ...UpbitClientOption) (client *UpbitClient, err error)
 
 	if len(errors) > 0 {
 		// 에러 발생 시 WebSocket 클라이언트만 정리
-		if client.PublicWS != nil {
-			client.PublicWS.Close()
-		}
-		if client.PrivateWS != nil {
-			client.PrivateWS.Close()
-		}
+		client.Close()
 		return nil, fmt.Errorf("클라이언트 초기화 에러: %v", errors)
 	}
 
+	if client.dryRun && client.RestAPI != nil {
+		client.RestAPI.GetExchange().SetDryRun(true, client.dryRunLog)
+	}
+
+	if client.expiryWarn != nil && client.RestAPI != nil && client.IsAuthenticated() {
+		client.checkKeyExpiry()
+	}
+
 	return client, nil
 }
+
+// Close는 공개/비공개 웹소켓 연결을 종료하고 키 교체 알림 등록을 해제합니다.
+// 생성하지 않은 클라이언트는 건너뛰며, 여러 번 호출해도 안전합니다.
+func (c *UpbitClient) Close() error {
+	if c.stopRotate != nil {
+		c.stopRotate()
+		c.stopRotate = nil
+	}
+
+	var errs []error
+	if c.PublicWS != nil {
+		if err := c.PublicWS.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("공개 웹소켓 종료 실패: %w", err))
+		}
+	}
+	if c.PrivateWS != nil {
+		if err := c.PrivateWS.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("비공개 웹소켓 종료 실패: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkKeyExpiry는 사용 중인 API 키의 만료 시각을 확인합니다.
+// 실패하면 CheckKeyExpiry가 expiryWarn에 에러를 전달합니다.
+func (c *UpbitClient) checkKeyExpiry() {
+	accessKey := ""
+	if c.keyPool == nil && c.signer == nil {
+		if creds, err := c.provider().Credentials(); err == nil {
+			accessKey = creds.AccessKey
+		}
+	}
+
+	c.RestAPI.GetExchange().CheckKeyExpiry(accessKey, c.expiryWithin, c.expiryWarn)
+}
+
+// websocketOptions는 웹소켓 클라이언트 생성에 사용할 옵션 목록을 반환합니다.
+func (c *UpbitClient) websocketOptions() []websocket.BaseClientOption {
+	var opts []websocket.BaseClientOption
+	if c.wsHTTPClient != nil {
+		opts = append(opts, websocket.WithHTTPClient(c.wsHTTPClient))
+	}
+	if c.lazyConnect {
+		opts = append(opts, websocket.WithLazyConnect())
+	}
+	return opts
+}
+
+// provider는 토큰 생성에 사용할 인증 정보 제공자를 반환합니다.
+// 인증 정보가 없으면 nil을 반환합니다.
+func (c *UpbitClient) provider() auth.CredentialsProvider {
+	if c.credsProvider != nil {
+		return c.credsProvider
+	}
+	if c.credentials.IsZero() {
+		return nil
+	}
+	return auth.NewStaticProvider(c.credentials)
+}
+
+// restTokenGen은 REST API용 토큰 생성기를 반환합니다.
+// 인증 정보가 없으면 nil을 반환합니다.
+func (c *UpbitClient) restTokenGen() rest.TokenGenerator {
+	if c.keyPool != nil {
+		return c.keyPool
+	}
+	if c.signer != nil {
+		return auth.NewRestTokenGenWithSigner(c.signer)
+	}
+	if c.provider() == nil {
+		return nil
+	}
+	return auth.NewRestTokenGenWithProvider(c.provider())
+}
+
+// wsTokenGen은 웹소켓용 토큰 생성기를 반환합니다.
+// 인증 정보가 없으면 nil을 반환합니다.
+func (c *UpbitClient) wsTokenGen() auth.WebSocketTokenGenerator {
+	if c.signer != nil {
+		return auth.NewWebSocketTokenGenWithSigner(c.signer)
+	}
+	if c.provider() == nil {
+		return nil
+	}
+	return auth.NewWebSocketTokenGenWithProvider(c.provider())
+}
@@ -0,0 +1,152 @@
+package upbit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	upbit "github.com/hysuki/go-upbit"
+	"github.com/hysuki/go-upbit/auth"
+	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+	"github.com/hysuki/go-upbit/upbittest"
+	"github.com/hysuki/go-upbit/websocket/private"
+)
+
+func TestKeyExpiryCheck(t *testing.T) {
+	type call struct {
+		key       exchange.APIKey
+		remaining time.Duration
+		err       error
+	}
+
+	tests := []struct {
+		name      string
+		expireIn  time.Duration
+		fail      bool
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "만료 임박", expireIn: 24 * time.Hour, wantCalls: 1},
+		{name: "만료까지 여유", expireIn: 30 * 24 * time.Hour, wantCalls: 0},
+		{name: "이미 만료", expireIn: -time.Hour, wantCalls: 1},
+		{name: "확인 실패", expireIn: 24 * time.Hour, fail: true, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := upbittest.NewServer(upbittest.WithKeyExpiry(time.Now().Add(tt.expireIn)))
+			defer srv.Close()
+			if tt.fail {
+				srv.Handle("GET", "/api_keys", upbittest.ErrorResponse(http.StatusInternalServerError, rest.ErrServerError, "internal error"))
+			}
+
+			var calls []call
+			opts := append(srv.ClientOptions(), upbit.WithREST(), upbit.WithKeyExpiryCheck(7*24*time.Hour, func(key exchange.APIKey, remaining time.Duration, err error) {
+				calls = append(calls, call{key, remaining, err})
+			}))
+			client, err := upbit.NewUpbitClient(opts...)
+			if err != nil {
+				t.Fatalf("NewUpbitClient error: %v", err)
+			}
+			defer client.Close()
+
+			if len(calls) != tt.wantCalls {
+				t.Fatalf("warn called %d times, want %d", len(calls), tt.wantCalls)
+			}
+			if tt.wantCalls == 0 {
+				return
+			}
+			c := calls[0]
+			if c.key.AccessKey != upbittest.DefaultAccessKey {
+				t.Errorf("AccessKey = %s, want %s", c.key.AccessKey, upbittest.DefaultAccessKey)
+			}
+			if tt.wantErr {
+				if !errors.Is(c.err, rest.ErrServerError) || c.remaining != 0 {
+					t.Errorf("warn(remaining=%v, err=%v), want 0 and server_error", c.remaining, c.err)
+				}
+				return
+			}
+			if c.err != nil {
+				t.Errorf("warn err = %v", c.err)
+			}
+			if diff := c.remaining - tt.expireIn; diff > time.Second || diff < -time.Minute {
+				t.Errorf("remaining = %v, want about %v", c.remaining, tt.expireIn)
+			}
+		})
+	}
+}
+
+func TestKeylessClient(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	client, err := upbit.NewUpbitClient(
+		upbit.WithRESTBaseURL(srv.BaseURL()),
+		upbit.WithWebsocketEndpoints(srv.PublicWebsocketURL(), srv.PrivateWebsocketURL()),
+	)
+	if err != nil {
+		t.Fatalf("NewUpbitClient error: %v", err)
+	}
+	defer client.Close()
+
+	if client.IsAuthenticated() {
+		t.Error("IsAuthenticated = true without keys")
+	}
+
+	// 시세 조회 API는 인증 없이 사용할 수 있습니다.
+	tickers, err := client.RestAPI.GetQuotation().GetTicker([]string{"KRW-BTC"})
+	if err != nil {
+		t.Fatalf("GetTicker error: %v", err)
+	}
+	if len(tickers) != 1 || tickers[0].TradePrice.String() != "50000000" {
+		t.Errorf("tickers = %+v", tickers)
+	}
+	reqs := srv.Requests()
+	if len(reqs) != 1 || reqs[0].Header.Get("Authorization") != "" {
+		t.Fatalf("requests = %+v, want one request without Authorization", reqs)
+	}
+
+	// 거래소 API는 요청을 보내지 않고 auth.ErrNotAuthenticated를 반환합니다.
+	ex := client.RestAPI.GetExchange()
+	calls := map[string]func() error{
+		"GetAccounts": func() error {
+			_, err := ex.GetAccounts()
+			return err
+		},
+		"GetOpenOrders": func() error {
+			_, err := ex.GetOpenOrders(&exchange.OpenOrderParams{Market: "KRW-BTC"})
+			return err
+		},
+		"CreateOrder": func() error {
+			_, err := ex.CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypePrice, Price: "10000"})
+			return err
+		},
+		"CancelOrder": func() error {
+			_, err := ex.CancelOrder(&exchange.CancelOrderParams{UUID: "uuid"})
+			return err
+		},
+		"WithdrawKRW": func() error {
+			_, err := ex.WithdrawKRW(&exchange.WithdrawKRWParams{Amount: "10000", TwoFactorType: exchange.TwoFactorTypeKakao})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, auth.ErrNotAuthenticated) {
+			t.Errorf("%s error = %v, want auth.ErrNotAuthenticated", name, err)
+		}
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("server received %d requests, want only the quotation request", n)
+	}
+
+	// 공개 웹소켓은 연결되고, 비공개 웹소켓은 연결하지 않습니다.
+	if n := srv.Connections(); n != 1 {
+		t.Errorf("Connections = %d, want 1", n)
+	}
+	err = client.PrivateWS.Subscribe(nil, private.AddSubscribe(private.MessageTypeMyOrder, nil, nil))
+	if !errors.Is(err, auth.ErrNotAuthenticated) {
+		t.Errorf("PrivateWS.Subscribe error = %v, want auth.ErrNotAuthenticated", err)
+	}
+}
@@ -0,0 +1,828 @@
+package upbittest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+)
+
+// registerExchange는 거래소 API의 기본 처리 함수를 등록합니다.
+func (s *Server) registerExchange() {
+	s.register("GET", "/accounts", true, s.handleAccounts)
+	s.register("GET", "/api_keys", true, s.handleAPIKeys)
+
+	s.register("GET", "/orders/chance", true, s.handleOrderChance)
+	s.register("POST", "/orders", true, s.handleCreateOrder)
+	s.register("POST", "/orders/test", true, s.handleTestOrder)
+	s.register("GET", "/order", true, s.handleGetOrder)
+	s.register("DELETE", "/order", true, s.handleCancelOrder)
+	s.register("POST", "/orders/cancel_and_new", true, s.handleCancelAndNewOrder)
+	s.register("DELETE", "/orders/uuids", true, s.handleCancelOrdersByID)
+	s.register("DELETE", "/orders/open", true, s.handleCancelOpenOrders)
+	s.register("GET", "/orders/uuids", true, s.handleOrdersByID)
+	s.register("GET", "/orders/open", true, s.handleOpenOrders)
+	s.register("GET", "/orders/closed", true, s.handleClosedOrders)
+
+	s.register("GET", "/deposits", true, s.transferList(func() []*transfer { return s.state.deposits }))
+	s.register("GET", "/deposit", true, s.transferGet(func() []*transfer { return s.state.deposits }))
+	s.register("GET", "/deposits/coin_addresses", true, s.handleDepositAddresses)
+	s.register("GET", "/deposits/coin_address", true, s.handleDepositAddress)
+	s.register("POST", "/deposits/generate_coin_address", true, s.handleGenerateCoinAddress)
+	s.register("GET", "/deposits/chance/coin", true, s.handleDepositChance)
+	s.register("POST", "/deposits/krw", true, s.handleDepositKRW)
+
+	s.register("GET", "/withdraws", true, s.transferList(func() []*transfer { return s.state.withdraws }))
+	s.register("GET", "/withdraw", true, s.transferGet(func() []*transfer { return s.state.withdraws }))
+	s.register("GET", "/withdraws/coin_addresses", true, s.handleWithdrawAddresses)
+	s.register("GET", "/withdraws/chance", true, s.handleWithdrawChance)
+	s.register("POST", "/withdraws/coin", true, s.handleWithdrawCoin)
+	s.register("POST", "/withdraws/krw", true, s.handleWithdrawKRW)
+}
+
+// validationError는 잘못된 요청에 대한 에러 응답을 생성합니다.
+func validationError(message string) Response {
+	return ErrorResponse(http.StatusBadRequest, rest.ErrValidationError, message)
+}
+
+// accountJSON은 잔고를 Upbit API 응답 형식으로 변환합니다.
+func accountJSON(currency string, a *account) map[string]interface{} {
+	return map[string]interface{}{
+		"currency":               currency,
+		"balance":                formatNumber(a.balance),
+		"locked":                 formatNumber(a.locked),
+		"avg_buy_price":          formatNumber(a.avgBuyPrice),
+		"avg_buy_price_modified": false,
+		"unit_currency":          "KRW",
+	}
+}
+
+func (s *Server) handleAccounts(url.Values) Response {
+	currencies := make([]string, 0, len(s.state.accounts))
+	for currency := range s.state.accounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	accounts := make([]map[string]interface{}, 0, len(currencies))
+	for _, currency := range currencies {
+		accounts = append(accounts, accountJSON(currency, s.state.accounts[currency]))
+	}
+	return JSON(http.StatusOK, accounts)
+}
+
+func (s *Server) handleAPIKeys(url.Values) Response {
+	return JSON(http.StatusOK, []map[string]interface{}{
+		{
+			"access_key": s.creds.AccessKey,
+			"expire_at":  s.expiry.Format(time.RFC3339),
+		},
+	})
+}
+
+func (s *Server) handleOrderChance(params url.Values) Response {
+	m := s.state.market(params.Get("market"))
+	if m == nil {
+		return validationError("market does not have a valid value")
+	}
+
+	quote, base := splitMarket(m.code)
+	constraint := func(currency string) map[string]interface{} {
+		return map[string]interface{}{
+			"currency":   currency,
+			"price_unit": nil,
+			"min_total":  formatNumber(minTotals[quote]),
+		}
+	}
+
+	return JSON(http.StatusOK, map[string]interface{}{
+		"bid_fee": "0.0005",
+		"ask_fee": "0.0005",
+		"market": map[string]interface{}{
+			"id":          m.code,
+			"name":        base + "/" + quote,
+			"order_types": []string{"limit"},
+			"ask_types":   []string{"limit", "market"},
+			"bid_types":   []string{"limit", "price"},
+			"order_sides": []string{"ask", "bid"},
+			"bid":         constraint(quote),
+			"ask":         constraint(base),
+			"max_total":   "1000000000.0",
+			"state":       "active",
+		},
+		"bid_account": accountJSON(quote, s.state.account(quote)),
+		"ask_account": accountJSON(base, s.state.account(base)),
+	})
+}
+
+// orderJSON은 주문을 Upbit API 응답 형식으로 변환합니다.
+func orderJSON(o *order) map[string]interface{} {
+	v := map[string]interface{}{
+		"uuid":             o.uuid,
+		"side":             o.side,
+		"ord_type":         o.ordType,
+		"state":            o.state,
+		"market":           o.market,
+		"created_at":       formatTime(o.createdAt),
+		"remaining_volume": formatNumber(o.volume - o.executedVolume),
+		"reserved_fee":     "0",
+		"remaining_fee":    "0",
+		"paid_fee":         "0",
+		"locked":           formatNumber(o.locked),
+		"executed_volume":  formatNumber(o.executedVolume),
+		"executed_funds":   formatNumber(o.executedFunds()),
+		"trades_count":     len(o.trades),
+	}
+	if o.ordType != "market" {
+		v["price"] = formatNumber(o.price)
+	}
+	if o.ordType != "price" || o.state == "done" {
+		v["volume"] = formatNumber(o.volume)
+	}
+	if o.identifier != "" {
+		v["identifier"] = o.identifier
+	}
+	if o.timeInForce != "" {
+		v["time_in_force"] = o.timeInForce
+	}
+	return v
+}
+
+func (s *Server) handleCreateOrder(params url.Values) Response {
+	o, m, errResp := s.newOrder(params)
+	if o == nil {
+		return errResp
+	}
+
+	// 주문 금액만큼 잔고를 묶어둡니다.
+	quote, base := splitMarket(m.code)
+	currency := base
+	if o.side == "bid" {
+		currency = quote
+	}
+	a := s.state.account(currency)
+	a.balance -= o.locked
+	a.locked += o.locked
+
+	s.state.orders = append(s.state.orders, o)
+	resp := orderJSON(o)
+	s.publishOrder(o)
+
+	// 시장가 주문은 현재가로 즉시 체결됩니다.
+	if o.ordType != "limit" {
+		s.state.fill(o, m.price)
+		s.publishOrder(o)
+	}
+
+	return JSON(http.StatusCreated, resp)
+}
+
+// handleTestOrder는 주문 생성 요청을 검증만 하고 주문을 생성하지 않습니다.
+func (s *Server) handleTestOrder(params url.Values) Response {
+	o, _, errResp := s.newOrder(params)
+	if o == nil {
+		return errResp
+	}
+	return JSON(http.StatusCreated, orderJSON(o))
+}
+
+// newOrder는 주문 생성 요청을 검증하고 묶어둘 금액이 설정된 주문을 생성합니다.
+// 잔고와 주문 목록은 바꾸지 않으며, 검증에 실패하면 nil과 에러 응답을 반환합니다.
+func (s *Server) newOrder(params url.Values) (*order, *market, Response) {
+	m := s.state.market(params.Get("market"))
+	if m == nil {
+		return nil, nil, validationError("market does not have a valid value")
+	}
+	quote, base := splitMarket(m.code)
+
+	o := &order{
+		uuid:        uuid.NewString(),
+		identifier:  params.Get("identifier"),
+		market:      m.code,
+		side:        params.Get("side"),
+		ordType:     params.Get("ord_type"),
+		state:       "wait",
+		timeInForce: params.Get("time_in_force"),
+		createdAt:   time.Now(),
+	}
+	if o.side != "bid" && o.side != "ask" {
+		return nil, nil, validationError("side does not have a valid value")
+	}
+	if o.identifier != "" && s.state.findOrder("", o.identifier) != nil {
+		return nil, nil, validationError("identifier already exists")
+	}
+
+	var ok bool
+	switch {
+	case o.ordType == "limit":
+		o.price, ok = parseNumber(params.Get("price"))
+		if ok {
+			o.volume, ok = parseNumber(params.Get("volume"))
+		}
+	case o.ordType == "price" && o.side == "bid":
+		o.price, ok = parseNumber(params.Get("price"))
+	case o.ordType == "market" && o.side == "ask":
+		o.volume, ok = parseNumber(params.Get("volume"))
+	}
+	if !ok {
+		return nil, nil, validationError("ord_type, price, volume does not have a valid value")
+	}
+
+	// 주문 금액과 잔고를 확인합니다.
+	if o.side == "bid" {
+		total := o.price
+		if o.ordType == "limit" {
+			total = o.price * o.volume
+		}
+		if total < minTotals[quote] {
+			return nil, nil, ErrorResponse(http.StatusBadRequest, rest.ErrUnderMinTotalBid, "최소주문금액 이상으로 주문해주세요")
+		}
+		if total > s.state.account(quote).balance {
+			return nil, nil, ErrorResponse(http.StatusBadRequest, rest.ErrInsufficientFundsBid, "주문가능한 금액(KRW)이 부족합니다.")
+		}
+		o.locked = total
+	} else {
+		price := o.price
+		if o.ordType == "market" {
+			price = m.price
+		}
+		if o.volume*price < minTotals[quote] {
+			return nil, nil, ErrorResponse(http.StatusBadRequest, rest.ErrUnderMinTotalAsk, "최소주문금액 이상으로 주문해주세요")
+		}
+		if o.volume > s.state.account(base).balance {
+			return nil, nil, ErrorResponse(http.StatusBadRequest, rest.ErrInsufficientFundsAsk, "주문가능한 금액("+base+")이 부족합니다.")
+		}
+		o.locked = o.volume
+	}
+
+	return o, m, Response{}
+}
+
+func (s *Server) handleGetOrder(params url.Values) Response {
+	id, identifier := params.Get("uuid"), params.Get("identifier")
+	if id == "" && identifier == "" {
+		return validationError("uuid or identifier is required")
+	}
+
+	o := s.state.findOrder(id, identifier)
+	if o == nil {
+		return ErrorResponse(http.StatusNotFound, rest.ErrOrderNotFound, "주문을 찾지 못했습니다.")
+	}
+
+	trend := "up"
+	if o.side == "ask" {
+		trend = "down"
+	}
+	trades := make([]map[string]interface{}, 0, len(o.trades))
+	for _, t := range o.trades {
+		trades = append(trades, map[string]interface{}{
+			"market":     o.market,
+			"uuid":       t.uuid,
+			"price":      formatNumber(t.price),
+			"volume":     formatNumber(t.volume),
+			"funds":      formatNumber(t.price * t.volume),
+			"trend":      trend,
+			"side":       o.side,
+			"created_at": formatTime(t.createdAt),
+		})
+	}
+
+	v := orderJSON(o)
+	v["trades"] = trades
+	return JSON(http.StatusOK, v)
+}
+
+func (s *Server) handleCancelOrder(params url.Values) Response {
+	id, identifier := params.Get("uuid"), params.Get("identifier")
+	if id == "" && identifier == "" {
+		return validationError("uuid or identifier is required")
+	}
+
+	o := s.state.findOrder(id, identifier)
+	if o == nil || o.state != "wait" {
+		return ErrorResponse(http.StatusNotFound, rest.ErrOrderNotFound, "주문을 찾지 못했습니다.")
+	}
+
+	s.state.cancel(o)
+	s.publishOrder(o)
+	return JSON(http.StatusOK, orderJSON(o))
+}
+
+func (s *Server) handleCancelAndNewOrder(params url.Values) Response {
+	id, identifier := params.Get("prev_order_uuid"), params.Get("prev_order_identifier")
+	if id == "" && identifier == "" {
+		return validationError("prev_order_uuid or prev_order_identifier is required")
+	}
+
+	prev := s.state.findOrder(id, identifier)
+	if prev == nil || prev.state != "wait" {
+		return ErrorResponse(http.StatusNotFound, rest.ErrOrderNotFound, "주문을 찾지 못했습니다.")
+	}
+
+	volume := params.Get("new_volume")
+	if volume == exchange.RemainOnly {
+		volume = formatNumber(prev.volume - prev.executedVolume)
+	}
+	newParams := url.Values{
+		"market":        {prev.market},
+		"side":          {prev.side},
+		"ord_type":      {params.Get("new_ord_type")},
+		"price":         {params.Get("new_price")},
+		"volume":        {volume},
+		"identifier":    {params.Get("new_identifier")},
+		"time_in_force": {params.Get("new_time_in_force")},
+	}
+
+	s.state.cancel(prev)
+	s.publishOrder(prev)
+
+	created := s.handleCreateOrder(newParams)
+	if created.StatusCode != http.StatusCreated {
+		return created
+	}
+
+	resp := orderJSON(prev)
+	resp["new_order_uuid"] = s.state.orders[len(s.state.orders)-1].uuid
+	if v := params.Get("new_identifier"); v != "" {
+		resp["new_order_identifier"] = v
+	}
+	return JSON(http.StatusCreated, resp)
+}
+
+func (s *Server) handleCancelOrdersByID(params url.Values) Response {
+	uuids, identifiers := params["uuids[]"], params["identifiers[]"]
+	if len(uuids) == 0 && len(identifiers) == 0 {
+		return validationError("uuids or identifiers is required")
+	}
+	if len(uuids) > 0 && len(identifiers) > 0 {
+		return validationError("uuids and identifiers cannot be used together")
+	}
+	if len(uuids) > 20 || len(identifiers) > 20 {
+		return validationError("uuids or identifiers cannot exceed 20")
+	}
+
+	var success, failed []map[string]interface{}
+	cancelOne := func(id, identifier string) {
+		o := s.state.findOrder(id, identifier)
+		if o == nil || o.state != "wait" {
+			if id != "" {
+				failed = append(failed, map[string]interface{}{"uuid": id})
+			} else {
+				failed = append(failed, map[string]interface{}{"identifier": identifier})
+			}
+			return
+		}
+		s.state.cancel(o)
+		s.publishOrder(o)
+		success = append(success, batchCancelJSON(o))
+	}
+	for _, id := range uuids {
+		cancelOne(id, "")
+	}
+	for _, identifier := range identifiers {
+		cancelOne("", identifier)
+	}
+	return JSON(http.StatusOK, batchCancelResult(success, failed))
+}
+
+func (s *Server) handleCancelOpenOrders(params url.Values) Response {
+	split := func(key string) []string {
+		if v := params.Get(key); v != "" {
+			return strings.Split(v, ",")
+		}
+		return nil
+	}
+	pairs, excluded, quotes := split("pairs"), split("excluded_pairs"), split("quote_currencies")
+	if len(pairs) > 0 && len(quotes) > 0 {
+		return validationError("pairs and quote_currencies cannot be used together")
+	}
+
+	side := params.Get("cancel_side")
+	if side == "" {
+		side = "all"
+	}
+	if side != "all" && side != "ask" && side != "bid" {
+		return validationError("cancel_side must be all, ask or bid")
+	}
+
+	count := 20
+	if v := params.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 300 {
+			return validationError("count must be between 1 and 300")
+		}
+		count = n
+	}
+
+	var success []map[string]interface{}
+	for _, o := range s.state.orders {
+		if len(success) >= count {
+			break
+		}
+		if o.state != "wait" || (side != "all" && o.side != side) {
+			continue
+		}
+		quote, _ := splitMarket(o.market)
+		if (len(pairs) > 0 && !contains(pairs, o.market)) || contains(excluded, o.market) ||
+			(len(quotes) > 0 && !contains(quotes, quote)) {
+			continue
+		}
+		s.state.cancel(o)
+		s.publishOrder(o)
+		success = append(success, batchCancelJSON(o))
+	}
+	return JSON(http.StatusOK, batchCancelResult(success, nil))
+}
+
+// batchCancelJSON은 주문을 일괄 취소 응답의 주문 형식으로 변환합니다.
+func batchCancelJSON(o *order) map[string]interface{} {
+	result := map[string]interface{}{"uuid": o.uuid, "market": o.market}
+	if o.identifier != "" {
+		result["identifier"] = o.identifier
+	}
+	return result
+}
+
+// batchCancelResult는 일괄 취소 응답을 생성합니다.
+func batchCancelResult(success, failed []map[string]interface{}) map[string]interface{} {
+	group := func(orders []map[string]interface{}) map[string]interface{} {
+		if orders == nil {
+			orders = []map[string]interface{}{}
+		}
+		return map[string]interface{}{"count": len(orders), "orders": orders}
+	}
+	return map[string]interface{}{"success": group(success), "failed": group(failed)}
+}
+
+func (s *Server) handleOrdersByID(params url.Values) Response {
+	uuids, identifiers := params["uuids[]"], params["identifiers[]"]
+	if len(uuids) == 0 && len(identifiers) == 0 {
+		return validationError("uuids or identifiers is required")
+	}
+
+	var orders []*order
+	for _, o := range s.state.orders {
+		if contains(uuids, o.uuid) || (o.identifier != "" && contains(identifiers, o.identifier)) {
+			orders = append(orders, o)
+		}
+	}
+	return s.orderList(orders, params, 0)
+}
+
+func (s *Server) handleOpenOrders(params url.Values) Response {
+	return s.filterOrders(params, []string{"wait", "watch"}, 100)
+}
+
+func (s *Server) handleClosedOrders(params url.Values) Response {
+	return s.filterOrders(params, []string{"done", "cancel"}, 1000)
+}
+
+// filterOrders는 상태와 마켓 조건에 맞는 주문 목록 응답을 생성합니다.
+// state 또는 states[] 파라미터가 없으면 defaultStates에 해당하는 주문을 반환합니다.
+func (s *Server) filterOrders(params url.Values, defaultStates []string, maxLimit int) Response {
+	states := params["states[]"]
+	if state := params.Get("state"); state != "" {
+		states = []string{state}
+	}
+	if len(states) == 0 {
+		states = defaultStates
+	}
+
+	var orders []*order
+	for _, o := range s.state.orders {
+		if !contains(states, o.state) {
+			continue
+		}
+		if market := params.Get("market"); market != "" && o.market != market {
+			continue
+		}
+		orders = append(orders, o)
+	}
+	return s.orderList(orders, params, maxLimit)
+}
+
+// orderList는 정렬과 페이지 조건을 적용한 주문 목록 응답을 생성합니다.
+func (s *Server) orderList(orders []*order, params url.Values, maxLimit int) Response {
+	orders = paginate(orders, params, maxLimit)
+
+	result := make([]map[string]interface{}, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, orderJSON(o))
+	}
+	return JSON(http.StatusOK, result)
+}
+
+// transferList는 입금 또는 출금 목록 조회를 처리하는 함수를 반환합니다.
+func (s *Server) transferList(records func() []*transfer) handlerFunc {
+	return func(params url.Values) Response {
+		uuids, txids := params["uuids[]"], params["txids[]"]
+
+		var result []*transfer
+		for _, t := range records() {
+			if currency := params.Get("currency"); currency != "" && t.Currency != currency {
+				continue
+			}
+			if state := params.Get("state"); state != "" && t.State != state {
+				continue
+			}
+			if len(uuids) > 0 && !contains(uuids, t.UUID) {
+				continue
+			}
+			if len(txids) > 0 && !contains(txids, t.TxID) {
+				continue
+			}
+			result = append(result, t)
+		}
+
+		result = paginate(result, params, 100)
+		if result == nil {
+			result = []*transfer{}
+		}
+		return JSON(http.StatusOK, result)
+	}
+}
+
+// transferGet은 uuid 또는 txid로 입금 또는 출금 하나를 조회하는 함수를 반환합니다.
+func (s *Server) transferGet(records func() []*transfer) handlerFunc {
+	return func(params url.Values) Response {
+		id, txid := params.Get("uuid"), params.Get("txid")
+		if id == "" && txid == "" {
+			return validationError("uuid or txid is required")
+		}
+		for _, t := range records() {
+			if (id != "" && t.UUID == id) || (txid != "" && t.TxID == txid) {
+				return JSON(http.StatusOK, t)
+			}
+		}
+		return ErrorResponse(http.StatusNotFound, "not_found", "내역을 찾지 못했습니다.")
+	}
+}
+
+func (s *Server) handleDepositAddresses(url.Values) Response {
+	return JSON(http.StatusOK, addressList(s.state.depositAddresses, "deposit_address"))
+}
+
+func (s *Server) handleDepositAddress(params url.Values) Response {
+	for _, a := range s.state.depositAddresses {
+		if a.Currency == params.Get("currency") && a.NetType == params.Get("net_type") {
+			return JSON(http.StatusOK, addressJSON(a, "deposit_address"))
+		}
+	}
+	return ErrorResponse(http.StatusNotFound, "coin_address_not_found", "입금 주소를 찾지 못했습니다.")
+}
+
+func (s *Server) handleGenerateCoinAddress(params url.Values) Response {
+	currency, netType := params.Get("currency"), params.Get("net_type")
+	if currency == "" || netType == "" {
+		return validationError("currency and net_type are required")
+	}
+
+	for _, a := range s.state.depositAddresses {
+		if a.Currency == currency && a.NetType == netType {
+			return JSON(http.StatusCreated, addressJSON(a, "deposit_address"))
+		}
+	}
+
+	// 실제 서버와 같이 처음 요청하면 주소 생성 중이라는 응답을 반환합니다.
+	s.state.depositAddresses = append(s.state.depositAddresses, &coinAddress{
+		Currency: currency,
+		NetType:  netType,
+		Address:  "upbittest-" + uuid.NewString(),
+	})
+	return JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": currency + " 입금주소를 생성중입니다.",
+	})
+}
+
+func (s *Server) handleDepositChance(params url.Values) Response {
+	currency, netType := params.Get("currency"), params.Get("net_type")
+	if currency == "" {
+		return validationError("currency is required")
+	}
+	if netType == "" {
+		netType = currency
+	}
+	return JSON(http.StatusOK, map[string]interface{}{
+		"currency":                      currency,
+		"net_type":                      netType,
+		"is_deposit_possible":           true,
+		"deposit_impossible_reason":     "",
+		"minimum_deposit_amount":        "0.0001",
+		"minimum_deposit_confirmations": 1,
+		"decimal_precision":             8,
+	})
+}
+
+func (s *Server) handleDepositKRW(params url.Values) Response {
+	amount, ok := parseNumber(params.Get("amount"))
+	if !ok || amount <= 0 {
+		return validationError("amount does not have a valid value")
+	}
+
+	t := newTransfer("deposit", "KRW", "", amount, 0)
+	s.state.deposits = append(s.state.deposits, t)
+	return JSON(http.StatusCreated, t)
+}
+
+func (s *Server) handleWithdrawAddresses(url.Values) Response {
+	return JSON(http.StatusOK, addressList(s.state.withdrawAddresses, "withdraw_address"))
+}
+
+func (s *Server) handleWithdrawChance(params url.Values) Response {
+	currency := params.Get("currency")
+	if currency == "" {
+		return validationError("currency is required")
+	}
+	return JSON(http.StatusOK, map[string]interface{}{
+		"member_level": map[string]interface{}{
+			"security_level":           3,
+			"fee_level":                0,
+			"email_verified":           true,
+			"identity_auth_verified":   true,
+			"bank_account_verified":    true,
+			"two_factor_auth_verified": true,
+			"locked":                   false,
+			"wallet_locked":            false,
+		},
+		"currency": map[string]interface{}{
+			"code":           currency,
+			"withdraw_fee":   withdrawFee(currency),
+			"is_coin":        currency != "KRW",
+			"wallet_state":   "working",
+			"wallet_support": []string{"deposit", "withdraw"},
+		},
+		"account": accountJSON(currency, s.state.account(currency)),
+		"withdraw_limit": map[string]interface{}{
+			"currency":              currency,
+			"minimum":               "0.0001",
+			"remaining_daily_fiat":  "1000000000",
+			"fiat_currency":         "KRW",
+			"withdraw_delayed_fiat": "0",
+			"fixed":                 8,
+			"can_withdraw":          true,
+		},
+	})
+}
+
+func (s *Server) handleWithdrawCoin(params url.Values) Response {
+	currency, netType := params.Get("currency"), params.Get("net_type")
+	amount, ok := parseNumber(params.Get("amount"))
+	if currency == "" || netType == "" || !ok || amount <= 0 {
+		return validationError("currency, net_type, amount does not have a valid value")
+	}
+
+	registered := false
+	for _, a := range s.state.withdrawAddresses {
+		if a.Currency == currency && a.NetType == netType && a.Address == params.Get("address") {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return ErrorResponse(http.StatusBadRequest, rest.ErrWithdrawAddressNotRegistered, "출금 허용 주소가 아닙니다.")
+	}
+
+	fee, _ := parseNumber(withdrawFee(currency))
+	if resp, ok := s.withdraw(currency, amount, fee); !ok {
+		return resp
+	}
+
+	t := newTransfer("withdraw", currency, netType, amount, fee)
+	t.KrwAmount = "0"
+	t.TransactionType = params.Get("transaction_type")
+	if t.TransactionType == "" {
+		t.TransactionType = "default"
+	}
+	s.state.withdraws = append(s.state.withdraws, t)
+	return JSON(http.StatusCreated, t)
+}
+
+func (s *Server) handleWithdrawKRW(params url.Values) Response {
+	amount, ok := parseNumber(params.Get("amount"))
+	if !ok || amount <= 0 {
+		return validationError("amount does not have a valid value")
+	}
+
+	fee, _ := parseNumber(withdrawFee("KRW"))
+	if resp, ok := s.withdraw("KRW", amount, fee); !ok {
+		return resp
+	}
+
+	t := newTransfer("withdraw", "KRW", "", amount, fee)
+	s.state.withdraws = append(s.state.withdraws, t)
+	return JSON(http.StatusCreated, t)
+}
+
+// withdraw는 출금 금액과 수수료만큼 잔고를 차감합니다.
+// 잔고가 부족하면 에러 응답과 false를 반환합니다.
+func (s *Server) withdraw(currency string, amount, fee float64) (Response, bool) {
+	a := s.state.account(currency)
+	if amount+fee > a.balance {
+		return validationError("출금 가능 금액이 부족합니다."), false
+	}
+	a.balance -= amount + fee
+	return Response{}, true
+}
+
+// newTransfer는 처리 중 상태의 입금 또는 출금 기록을 생성합니다.
+func newTransfer(typ, currency, netType string, amount, fee float64) *transfer {
+	state := "PROCESSING"
+	if typ == "withdraw" {
+		state = "WAITING"
+	}
+	return &transfer{
+		Type:            typ,
+		UUID:            uuid.NewString(),
+		Currency:        currency,
+		NetType:         netType,
+		State:           state,
+		CreatedAt:       formatTime(time.Now()),
+		Amount:          formatNumber(amount),
+		Fee:             formatNumber(fee),
+		TransactionType: "default",
+	}
+}
+
+// withdrawFee는 화폐별 출금 수수료를 반환합니다.
+func withdrawFee(currency string) string {
+	switch currency {
+	case "KRW":
+		return "1000"
+	case "BTC":
+		return "0.0005"
+	default:
+		return "0"
+	}
+}
+
+// addressJSON은 주소를 Upbit API 응답 형식으로 변환합니다.
+// key는 주소 필드 이름(deposit_address, withdraw_address)입니다.
+func addressJSON(a *coinAddress, key string) map[string]interface{} {
+	return map[string]interface{}{
+		"currency":          a.Currency,
+		"net_type":          a.NetType,
+		"network_name":      a.NetType,
+		key:                 a.Address,
+		"secondary_address": a.SecondaryAddress,
+	}
+}
+
+// addressList는 주소 목록을 Upbit API 응답 형식으로 변환합니다.
+func addressList(addresses []*coinAddress, key string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(addresses))
+	for _, a := range addresses {
+		result = append(result, addressJSON(a, key))
+	}
+	return result
+}
+
+// paginate는 order_by, page, limit 파라미터를 적용한 목록을 반환합니다.
+// 목록은 생성 순서로 저장되어 있으며, 기본 정렬은 최신순(desc)입니다.
+func paginate[T any](items []T, params url.Values, maxLimit int) []T {
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+	if params.Get("order_by") != "asc" {
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	if limit <= 0 || (maxLimit > 0 && limit > maxLimit) {
+		limit = maxLimit
+	}
+	if limit <= 0 {
+		return sorted
+	}
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start >= len(sorted) {
+		return nil
+	}
+	end := start + limit
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+	return sorted[start:end]
+}
+
+// contains는 values에 v가 있는지 확인합니다.
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,305 @@
+package upbittest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redacted는 픽스처에서 가려진 값을 대신하는 문자열입니다.
+const redacted = "REDACTED"
+
+// Fixture는 녹화된 REST API 요청과 응답 한 쌍입니다.
+// 요청의 Authorization 헤더는 저장하지 않습니다.
+type Fixture struct {
+	Method     string          `json:"method"`           // HTTP 메서드
+	Path       string          `json:"path"`             // 요청 경로 (예: /v1/ticker)
+	Query      string          `json:"query,omitempty"`  // 정규화된 쿼리 문자열 또는 form 본문
+	StatusCode int             `json:"status_code"`      // HTTP 상태 코드
+	Header     http.Header     `json:"header,omitempty"` // 응답 헤더
+	Body       json.RawMessage `json:"body"`             // 응답 본문 (JSON이 아니면 JSON 문자열로 저장)
+}
+
+// key는 재생할 때 요청과 픽스처를 대응시키는 키입니다.
+func (f *Fixture) key() string {
+	return f.Method + " " + f.Path + "?" + f.Query
+}
+
+// body는 저장된 응답 본문을 원래 형태로 반환합니다.
+func (f *Fixture) body() []byte {
+	var s string
+	if json.Unmarshal(f.Body, &s) == nil {
+		return []byte(s)
+	}
+	return f.Body
+}
+
+// normalizeQuery는 쿼리 문자열을 키 순서로 정렬한 형태로 변환합니다.
+// 같은 키의 값 순서는 유지합니다.
+func normalizeQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	query, _ := url.QueryUnescape(values.Encode())
+	return query
+}
+
+// requestQuery는 요청의 쿼리 문자열 또는 form 본문을 정규화해 반환합니다.
+// 본문을 읽은 경우 다시 읽을 수 있도록 req.Body를 교체합니다.
+func requestQuery(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return normalizeQuery(req.URL.RawQuery), nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return normalizeQuery(string(body)), nil
+}
+
+// Recorder는 실제 Upbit API와 주고받은 요청과 응답을 픽스처 파일로 저장하는
+// http.RoundTripper입니다. rest.WithHTTPClient에 Client()를 전달해 사용합니다.
+//
+//	rec := upbittest.NewRecorder("testdata/fixtures", nil, secretKey)
+//	client := rest.NewClient(tokenGen, rest.WithHTTPClient(rec.Client()))
+//
+// 픽스처는 요청마다 하나의 JSON 파일로 저장되며, 같은 요청을 여러 번 보내면
+// 순번을 붙여 별도의 파일로 저장합니다. Authorization 헤더는 저장하지 않고,
+// NewRecorder에 지정한 비밀 값은 쿼리와 응답에서 REDACTED로 바뀝니다.
+type Recorder struct {
+	dir     string
+	next    http.RoundTripper
+	secrets []string
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewRecorder는 dir에 픽스처를 저장하는 Recorder를 생성합니다.
+// next가 nil이면 http.DefaultTransport로 요청을 보내며, secrets는 픽스처에서
+// 가릴 문자열(API 키, 출금 주소 등)입니다.
+func NewRecorder(dir string, next http.RoundTripper, secrets ...string) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{
+		dir:     dir,
+		next:    next,
+		secrets: secrets,
+		counts:  make(map[string]int),
+	}
+}
+
+// Client는 Recorder를 Transport로 사용하는 HTTP 클라이언트를 반환합니다.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip은 요청을 전달하고 응답을 픽스처로 저장합니다.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	query, err := requestQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := &Fixture{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Query:      r.redact(query),
+		StatusCode: resp.StatusCode,
+		Header:     make(http.Header),
+	}
+	for key, values := range resp.Header {
+		// 본문이 가려지면 길이가 달라지므로 Content-Length는 저장하지 않습니다.
+		if key == "Set-Cookie" || key == "Content-Length" {
+			continue
+		}
+		for _, value := range values {
+			fixture.Header.Add(key, r.redact(value))
+		}
+	}
+	if redactedBody := r.redact(string(body)); json.Valid([]byte(redactedBody)) {
+		fixture.Body = json.RawMessage(redactedBody)
+	} else {
+		fixture.Body, _ = json.Marshal(redactedBody)
+	}
+
+	if err := r.save(fixture); err != nil {
+		return nil, fmt.Errorf("failed to save fixture: %w", err)
+	}
+	return resp, nil
+}
+
+// redact는 s에 포함된 비밀 값을 가립니다.
+func (r *Recorder) redact(s string) string {
+	return redactSecrets(s, r.secrets)
+}
+
+// redactSecrets는 s에 포함된 secrets를 REDACTED로 바꿉니다.
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+// save는 픽스처를 파일로 저장합니다.
+// 파일 이름은 메서드, 경로, 쿼리 해시와 같은 요청 안에서의 순번으로 구성됩니다.
+func (r *Recorder) save(f *Fixture) error {
+	r.mu.Lock()
+	r.counts[f.key()]++
+	seq := r.counts[f.key()]
+	r.mu.Unlock()
+
+	hash := sha256.Sum256([]byte(f.key()))
+	name := strings.ToLower(f.Method) + strings.ReplaceAll(f.Path, "/", "_")
+	name = fmt.Sprintf("%s_%s_%03d.json", name, hex.EncodeToString(hash[:4]), seq)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0o644)
+}
+
+// Replayer는 Recorder가 저장한 픽스처로 응답하는 http.RoundTripper입니다.
+// 요청은 메서드, 경로, 정규화된 쿼리로 픽스처와 대응시키며, 호스트는 비교하지 않습니다.
+//
+//	rep, err := upbittest.NewReplayer("testdata/fixtures", accessKey, secretKey)
+//	client := rest.NewClient(tokenGen, rest.WithHTTPClient(rep.Client()))
+//
+// 녹화할 때 쿼리의 비밀 값이 가려졌다면 Recorder에 지정한 것과 같은 비밀 값을 지정해야
+// 요청의 쿼리도 같은 방식으로 가려져 픽스처와 대응됩니다.
+// 같은 요청의 픽스처가 여러 개이면 녹화된 순서대로 반환하고,
+// 모두 사용한 뒤에는 마지막 픽스처를 계속 반환합니다.
+type Replayer struct {
+	mu       sync.Mutex
+	fixtures map[string][]*Fixture
+	served   map[string]int
+	secrets  []string
+}
+
+// NewReplayer는 dir의 픽스처 파일(*.json)을 읽어 Replayer를 생성합니다.
+// secrets는 녹화할 때 NewRecorder에 지정한 비밀 값으로, 요청의 쿼리에서 가린 뒤 픽스처와 비교합니다.
+func NewReplayer(dir string, secrets ...string) (*Replayer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f Fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		fixtures = append(fixtures, &f)
+	}
+	r := NewReplayerFromFixtures(fixtures...)
+	r.SetSecrets(secrets...)
+	return r, nil
+}
+
+// NewReplayerFromFixtures는 주어진 픽스처로 응답하는 Replayer를 생성합니다.
+func NewReplayerFromFixtures(fixtures ...*Fixture) *Replayer {
+	r := &Replayer{
+		fixtures: make(map[string][]*Fixture),
+		served:   make(map[string]int),
+	}
+	for _, f := range fixtures {
+		f.Query = normalizeQuery(f.Query)
+		r.fixtures[f.key()] = append(r.fixtures[f.key()], f)
+	}
+	return r
+}
+
+// SetSecrets는 요청의 쿼리에서 가릴 비밀 값을 설정합니다.
+// NewReplayerFromFixtures로 만든 Replayer에 녹화 시 가려진 픽스처를 사용할 때 지정합니다.
+func (r *Replayer) SetSecrets(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets = append([]string(nil), secrets...)
+}
+
+// Client는 Replayer를 Transport로 사용하는 HTTP 클라이언트를 반환합니다.
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip은 요청에 대응하는 픽스처의 응답을 반환합니다.
+// 대응하는 픽스처가 없으면 에러를 반환합니다.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	query, err := requestQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	// Recorder와 같은 방식으로 비밀 값을 가린 쿼리로 픽스처를 찾습니다.
+	query = redactSecrets(query, r.secrets)
+	key := req.Method + " " + req.URL.Path + "?" + query
+	candidates := r.fixtures[key]
+	if len(candidates) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no fixture for %s %s?%s", req.Method, req.URL.Path, query)
+	}
+	i := r.served[key]
+	if i < len(candidates)-1 {
+		r.served[key]++
+	}
+	f := candidates[i]
+	r.mu.Unlock()
+
+	body := f.body()
+	header := f.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
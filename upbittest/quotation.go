@@ -0,0 +1,319 @@
+package upbittest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 캔들 기준 시각 형식입니다.
+const candleTimeLayout = "2006-01-02T15:04:05"
+
+// registerQuotation은 시세 조회 API의 기본 처리 함수를 등록합니다.
+func (s *Server) registerQuotation() {
+	s.register("GET", "/market/all", false, s.handleMarkets)
+	s.register("GET", "/ticker", false, s.handleTicker)
+	s.register("GET", "/ticker/all", false, s.handleTickerAll)
+	s.register("GET", "/orderbook", false, s.handleOrderbook)
+	s.register("GET", "/orderbook/supported_levels", false, s.handleSupportedLevels)
+	s.register("GET", "/trades/ticks", false, s.handleTrades)
+
+	for _, unit := range []int{1, 3, 5, 10, 15, 30, 60, 240} {
+		unit := unit
+		interval := time.Duration(unit) * time.Minute
+		s.register("GET", fmt.Sprintf("/candles/minutes/%d", unit), false, s.candles(func(t time.Time, n int) time.Time {
+			return t.Truncate(interval).Add(-time.Duration(n) * interval)
+		}, func(v map[string]interface{}, _ time.Time, _ *market, _ url.Values) {
+			v["unit"] = unit
+		}))
+	}
+	s.register("GET", "/candles/days", false, s.candles(func(t time.Time, n int) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()-n, 0, 0, 0, 0, time.UTC)
+	}, func(v map[string]interface{}, _ time.Time, m *market, params url.Values) {
+		v["prev_closing_price"] = m.price
+		v["change_price"] = 0
+		v["change_rate"] = 0
+		if params.Has("converting_price_unit") {
+			v["converted_trade_price"] = m.price
+		}
+	}))
+	s.register("GET", "/candles/weeks", false, s.candles(func(t time.Time, n int) time.Time {
+		monday := t.Day() - (int(t.Weekday())+6)%7
+		return time.Date(t.Year(), t.Month(), monday-7*n, 0, 0, 0, 0, time.UTC)
+	}, firstDayOfPeriod))
+	s.register("GET", "/candles/months", false, s.candles(func(t time.Time, n int) time.Time {
+		return time.Date(t.Year(), t.Month()-time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	}, firstDayOfPeriod))
+	s.register("GET", "/candles/years", false, s.candles(func(t time.Time, n int) time.Time {
+		return time.Date(t.Year()-n, 1, 1, 0, 0, 0, 0, time.UTC)
+	}, firstDayOfPeriod))
+}
+
+// notFoundMarket은 존재하지 않는 마켓 코드에 대한 에러 응답입니다.
+func notFoundMarket() Response {
+	return ErrorResponse(http.StatusNotFound, "404", "Code not found")
+}
+
+// markets는 쉼표로 구분된 마켓 코드 목록에 해당하는 마켓을 반환합니다.
+// 없는 마켓이 있으면 false를 반환합니다.
+func (s *Server) markets(codes string) ([]*market, bool) {
+	var result []*market
+	for _, code := range strings.Split(codes, ",") {
+		m := s.state.market(strings.TrimSpace(code))
+		if m == nil {
+			return nil, false
+		}
+		result = append(result, m)
+	}
+	return result, true
+}
+
+func (s *Server) handleMarkets(params url.Values) Response {
+	details := params.Get("is_details") == "true"
+
+	result := make([]map[string]interface{}, 0, len(s.state.markets))
+	for _, m := range s.state.markets {
+		v := map[string]interface{}{
+			"market":       m.code,
+			"korean_name":  m.koreanName,
+			"english_name": m.englishName,
+		}
+		if details {
+			v["market_event"] = map[string]interface{}{
+				"warning": m.warning,
+				"caution": map[string]bool{
+					"PRICE_FLUCTUATIONS":              false,
+					"TRADING_VOLUME_SOARING":          false,
+					"DEPOSIT_AMOUNT_SOARING":          false,
+					"GLOBAL_PRICE_DIFFERENCES":        false,
+					"CONCENTRATION_OF_SMALL_ACCOUNTS": false,
+				},
+			}
+		}
+		result = append(result, v)
+	}
+	return JSON(http.StatusOK, result)
+}
+
+// tickerJSON은 마켓의 현재가 정보를 Upbit API 응답 형식으로 생성합니다.
+// 모의 서버의 시세는 변하지 않으므로 시가, 고가, 저가, 종가가 모두 같습니다.
+func tickerJSON(m *market, now time.Time) map[string]interface{} {
+	utc := now.UTC()
+	local := now.In(kst)
+	return map[string]interface{}{
+		"market":                m.code,
+		"trade_date":            utc.Format("20060102"),
+		"trade_time":            utc.Format("150405"),
+		"trade_date_kst":        local.Format("20060102"),
+		"trade_time_kst":        local.Format("150405"),
+		"trade_timestamp":       now.UnixMilli(),
+		"opening_price":         m.price,
+		"high_price":            m.price,
+		"low_price":             m.price,
+		"trade_price":           m.price,
+		"prev_closing_price":    m.price,
+		"change":                "EVEN",
+		"change_price":          0,
+		"change_rate":           0,
+		"signed_change_price":   0,
+		"signed_change_rate":    0,
+		"trade_volume":          1,
+		"acc_trade_price":       m.price * 100,
+		"acc_trade_price_24h":   m.price * 100,
+		"acc_trade_volume":      100,
+		"acc_trade_volume_24h":  100,
+		"highest_52_week_price": m.price,
+		"highest_52_week_date":  local.Format(time.DateOnly),
+		"lowest_52_week_price":  m.price,
+		"lowest_52_week_date":   local.Format(time.DateOnly),
+		"timestamp":             now.UnixMilli(),
+	}
+}
+
+func (s *Server) handleTicker(params url.Values) Response {
+	markets, ok := s.markets(params.Get("markets"))
+	if !ok {
+		return notFoundMarket()
+	}
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(markets))
+	for _, m := range markets {
+		result = append(result, tickerJSON(m, now))
+	}
+	return JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleTickerAll(params url.Values) Response {
+	quotes := strings.Split(params.Get("quote_currencies"), ",")
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(s.state.markets))
+	for _, m := range s.state.markets {
+		quote, _ := splitMarket(m.code)
+		if contains(quotes, quote) {
+			result = append(result, tickerJSON(m, now))
+		}
+	}
+	return JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleOrderbook(params url.Values) Response {
+	markets, ok := s.markets(params.Get("markets"))
+	if !ok {
+		return notFoundMarket()
+	}
+	level, _ := strconv.ParseFloat(params.Get("level"), 64)
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(markets))
+	for _, m := range markets {
+		// 현재가를 기준으로 0.1% 간격의 호가 15개를 생성합니다.
+		tick := m.price * 0.001
+		units := make([]map[string]float64, 0, 15)
+		for i := 1; i <= 15; i++ {
+			units = append(units, map[string]float64{
+				"ask_price": m.price + tick*float64(i),
+				"bid_price": m.price - tick*float64(i),
+				"ask_size":  1,
+				"bid_size":  1,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"market":          m.code,
+			"timestamp":       now.UnixMilli(),
+			"total_ask_size":  15,
+			"total_bid_size":  15,
+			"orderbook_units": units,
+			"level":           level,
+		})
+	}
+	return JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleSupportedLevels(params url.Values) Response {
+	markets, ok := s.markets(params.Get("markets"))
+	if !ok {
+		return notFoundMarket()
+	}
+
+	result := make([]map[string]interface{}, 0, len(markets))
+	for _, m := range markets {
+		result = append(result, map[string]interface{}{
+			"market":           m.code,
+			"supported_levels": []float64{0},
+		})
+	}
+	return JSON(http.StatusOK, result)
+}
+
+func (s *Server) handleTrades(params url.Values) Response {
+	m := s.state.market(params.Get("market"))
+	if m == nil {
+		return notFoundMarket()
+	}
+
+	count := countParam(params, 1, 500)
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		t := now.Add(-time.Duration(i) * time.Second).UTC()
+		askBid := "BID"
+		if i%2 == 1 {
+			askBid = "ASK"
+		}
+		result = append(result, map[string]interface{}{
+			"market":             m.code,
+			"trade_date_utc":     t.Format(time.DateOnly),
+			"trade_time_utc":     t.Format(time.TimeOnly),
+			"timestamp":          t.UnixMilli(),
+			"trade_price":        m.price,
+			"trade_volume":       1,
+			"prev_closing_price": m.price,
+			"change_price":       0,
+			"ask_bid":            askBid,
+			"sequential_id":      t.UnixMicro(),
+		})
+	}
+	return JSON(http.StatusOK, result)
+}
+
+// candleDecorator는 캔들 종류별 추가 필드를 설정하는 함수입니다.
+type candleDecorator func(v map[string]interface{}, t time.Time, m *market, params url.Values)
+
+// firstDayOfPeriod는 주, 월, 연 캔들에 기간의 첫 날을 설정합니다.
+func firstDayOfPeriod(v map[string]interface{}, t time.Time, _ *market, _ url.Values) {
+	v["first_day_of_period"] = t.Format(time.DateOnly)
+}
+
+// candles는 캔들 조회를 처리하는 함수를 반환합니다.
+// start는 기준 시각 t로부터 n번째 이전 캔들의 시작 시각을 계산하며,
+// decorate는 캔들 종류별 추가 필드를 설정합니다.
+func (s *Server) candles(start func(t time.Time, n int) time.Time, decorate candleDecorator) handlerFunc {
+	return func(params url.Values) Response {
+		m := s.state.market(params.Get("market"))
+		if m == nil {
+			return notFoundMarket()
+		}
+
+		to := time.Now().UTC()
+		if v := params.Get("to"); v != "" {
+			t, err := parseCandleTime(v)
+			if err != nil {
+				return validationError("to does not have a valid value")
+			}
+			// to 시각 이전의 캔들을 반환합니다.
+			to = t.UTC().Add(-time.Nanosecond)
+		}
+
+		count := countParam(params, 1, 200)
+		result := make([]map[string]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			t := start(to, i)
+			v := map[string]interface{}{
+				"market":                  m.code,
+				"candle_date_time_utc":    t.Format(candleTimeLayout),
+				"candle_date_time_kst":    t.In(kst).Format(candleTimeLayout),
+				"opening_price":           m.price,
+				"high_price":              m.price,
+				"low_price":               m.price,
+				"trade_price":             m.price,
+				"timestamp":               t.UnixMilli(),
+				"candle_acc_trade_price":  m.price * 10,
+				"candle_acc_trade_volume": 10,
+			}
+			decorate(v, t, m, params)
+			result = append(result, v)
+		}
+		return JSON(http.StatusOK, result)
+	}
+}
+
+// parseCandleTime은 캔들 조회의 to 파라미터를 파싱합니다.
+// 시간대가 없는 형식은 UTC로 해석합니다.
+func parseCandleTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{candleTimeLayout, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", v)
+}
+
+// countParam은 count 파라미터를 반환합니다. 없으면 def, max보다 크면 max를 반환합니다.
+func countParam(params url.Values, def, max int) int {
+	count, err := strconv.Atoi(params.Get("count"))
+	if err != nil || count <= 0 {
+		return def
+	}
+	if count > max {
+		return max
+	}
+	return count
+}
@@ -0,0 +1,71 @@
+package upbittest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hysuki/go-upbit/rest"
+)
+
+// groupLimits는 모의 서버가 Remaining-Req 헤더로 알려주는 그룹별 초당 요청 수입니다.
+var groupLimits = map[string]int{
+	rest.GroupDefault:        30,
+	rest.GroupOrder:          8,
+	rest.GroupOrderTest:      8,
+	rest.GroupOrderCancelAll: 1,
+	rest.GroupMarket:         10,
+	rest.GroupCandles:        10,
+	rest.GroupTrades:         10,
+	rest.GroupTicker:         10,
+	rest.GroupOrderbook:      10,
+}
+
+// Response는 모의 서버가 반환할 응답입니다.
+type Response struct {
+	StatusCode int         // HTTP 상태 코드
+	Body       string      // 응답 본문 (JSON 문자열)
+	Header     http.Header // 추가 응답 헤더
+}
+
+// JSON은 v를 JSON으로 인코딩한 본문을 가진 응답을 생성합니다.
+// v가 string이나 []byte이면 그대로 본문으로 사용합니다.
+func JSON(statusCode int, v interface{}) Response {
+	switch v := v.(type) {
+	case string:
+		return Response{StatusCode: statusCode, Body: v}
+	case []byte:
+		return Response{StatusCode: statusCode, Body: string(v)}
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return ErrorResponse(http.StatusInternalServerError, rest.ErrServerError, err.Error())
+	}
+	return Response{StatusCode: statusCode, Body: string(body)}
+}
+
+// ErrorResponse는 Upbit API 에러 형식({"error":{"name":..., "message":...}})의 응답을 생성합니다.
+func ErrorResponse(statusCode int, name rest.ErrorCode, message string) Response {
+	return JSON(statusCode, rest.ErrorResponse{
+		Error: rest.APIError{Name: string(name), Message: message},
+	})
+}
+
+// writeResponse는 resp를 w에 기록합니다.
+func writeResponse(w http.ResponseWriter, resp Response) {
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	statusCode := resp.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	w.Write([]byte(resp.Body))
+}
@@ -0,0 +1,315 @@
+// Package upbittest는 네트워크 없이 go-upbit 클라이언트를 테스트하기 위한
+// Upbit API 모의 서버를 제공합니다.
+//
+// Server는 httptest.Server 위에서 거래소 API(자산, 주문, 입출금)와
+// 시세 조회 API(캔들, 현재가, 호가, 체결)를 흉내 내며, 요청의 JWT 서명과
+// query_hash를 실제 서버와 같은 방식으로 검증합니다.
+// 같은 주소에서 공개/비공개 웹소켓 API도 제공하므로 구독, 메시지 수신,
+// 연결 끊김 후 재연결 동작도 테스트할 수 있습니다.
+//
+//	srv := upbittest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	accounts, err := client.GetExchange().GetAccounts()
+package upbittest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	upbit "github.com/hysuki/go-upbit"
+	"github.com/hysuki/go-upbit/auth"
+	"github.com/hysuki/go-upbit/rest"
+)
+
+// 모의 서버가 기본으로 사용하는 API 키입니다.
+const (
+	DefaultAccessKey = "upbittest-access-key"
+	DefaultSecretKey = "upbittest-secret-key"
+)
+
+// restPrefix는 REST API 경로의 접두사입니다.
+const restPrefix = "/v1"
+
+// Request는 모의 서버가 수신한 REST API 요청입니다.
+type Request struct {
+	Method string                 // HTTP 메서드
+	Path   string                 // /v1을 제외한 API 경로
+	Params url.Values             // 쿼리 파라미터 또는 form 본문
+	Header http.Header            // 요청 헤더
+	Claims map[string]interface{} // 인증 토큰의 페이로드 (인증 없는 요청은 nil)
+}
+
+// handlerFunc는 검증을 통과한 요청을 처리하는 함수입니다.
+type handlerFunc func(params url.Values) Response
+
+// route는 API 경로별 처리 방식입니다.
+type route struct {
+	private bool        // 인증이 필요한 API 여부
+	handle  handlerFunc // 기본 응답 생성 함수
+}
+
+// Server는 Upbit REST API와 웹소켓 API 모의 서버입니다.
+type Server struct {
+	URL string // 서버 주소 (예: http://127.0.0.1:12345)
+
+	srv     *httptest.Server
+	creds   auth.Credentials
+	expiry  time.Time         // API 키 만료 시각
+	limiter *rest.RateLimiter // 요청 그룹 판별용
+
+	mu        sync.Mutex
+	routes    map[string]route
+	overrides map[string]Response   // "METHOD path" → 항상 반환할 응답
+	queues    map[string][]Response // "METHOD path" → 한 번씩 반환할 응답
+	requests  []Request
+	nonces    map[string]bool
+	state     *state
+
+	conns         map[*wsConn]struct{} // 연결된 웹소켓 클라이언트
+	subscriptions []Subscription       // 수신한 구독 요청
+	subscribed    chan struct{}        // 구독 요청을 수신하면 닫히는 채널
+}
+
+// ServerOption은 모의 서버의 설정을 변경하는 함수 타입입니다.
+type ServerOption func(*Server)
+
+// WithCredentials는 모의 서버가 토큰 검증에 사용할 API 키를 설정하는 옵션을 반환합니다.
+// 기본값은 DefaultAccessKey, DefaultSecretKey입니다.
+func WithCredentials(accessKey, secretKey string) ServerOption {
+	return func(s *Server) {
+		s.creds = auth.Credentials{AccessKey: accessKey, SecretKey: secretKey}
+	}
+}
+
+// WithKeyExpiry는 /api_keys가 반환하는 API 키 만료 시각을 설정하는 옵션을 반환합니다.
+// 기본값은 서버 시작 시각으로부터 1년 뒤입니다.
+func WithKeyExpiry(expireAt time.Time) ServerOption {
+	return func(s *Server) {
+		s.expiry = expireAt
+	}
+}
+
+// NewServer는 새로운 모의 서버를 시작합니다.
+// 사용이 끝나면 Close를 호출해야 합니다.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		creds:      auth.Credentials{AccessKey: DefaultAccessKey, SecretKey: DefaultSecretKey},
+		expiry:     time.Now().AddDate(1, 0, 0),
+		limiter:    rest.NewRateLimiter(rest.RateLimitDisabled),
+		overrides:  make(map[string]Response),
+		queues:     make(map[string][]Response),
+		nonces:     make(map[string]bool),
+		state:      newState(),
+		conns:      make(map[*wsConn]struct{}),
+		subscribed: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.routes = make(map[string]route)
+	s.registerExchange()
+	s.registerQuotation()
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close는 웹소켓 연결을 끊고 모의 서버를 종료합니다.
+func (s *Server) Close() {
+	s.DropConnections()
+	s.srv.Close()
+}
+
+// BaseURL은 rest.WithBaseURL에 전달할 REST API 기본 URL을 반환합니다.
+func (s *Server) BaseURL() string {
+	return s.URL + restPrefix
+}
+
+// Credentials는 모의 서버가 토큰 검증에 사용하는 API 키를 반환합니다.
+func (s *Server) Credentials() auth.Credentials {
+	return s.creds
+}
+
+// Client는 모의 서버에 연결된 REST API 클라이언트를 생성합니다.
+// opts는 기본 URL 설정 뒤에 적용됩니다.
+func (s *Server) Client(opts ...rest.ClientOption) rest.Client {
+	opts = append([]rest.ClientOption{rest.WithBaseURL(s.BaseURL())}, opts...)
+	return rest.NewClient(auth.NewRestTokenGen(s.creds), opts...)
+}
+
+// ClientOptions는 upbit.NewUpbitClient가 모의 서버의 REST API와 웹소켓 API를
+// 사용하도록 하는 옵션을 반환합니다.
+//
+//	client, err := upbit.NewUpbitClient(srv.ClientOptions()...)
+func (s *Server) ClientOptions() []upbit.UpbitClientOption {
+	return []upbit.UpbitClientOption{
+		upbit.WithKeys(s.creds.AccessKey, s.creds.SecretKey),
+		upbit.WithRESTBaseURL(s.BaseURL()),
+		upbit.WithWebsocketEndpoints(s.PublicWebsocketURL(), s.PrivateWebsocketURL()),
+	}
+}
+
+// Handle은 method와 path 요청에 항상 resp를 반환하도록 설정합니다.
+// path는 /v1을 제외한 API 경로이며, 인증 검증은 그대로 수행됩니다.
+func (s *Server) Handle(method, path string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[method+" "+path] = resp
+}
+
+// Enqueue는 method와 path 요청에 resps를 순서대로 한 번씩 반환하도록 설정합니다.
+// 준비된 응답을 모두 사용하면 Handle로 지정한 응답이나 기본 응답을 반환합니다.
+func (s *Server) Enqueue(method, path string, resps ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := method + " " + path
+	s.queues[key] = append(s.queues[key], resps...)
+}
+
+// Requests는 지금까지 수신한 요청 목록을 반환합니다.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset은 설정한 응답과 요청/구독 기록, 주문/입출금 상태를 초기화합니다.
+// 웹소켓 연결은 유지됩니다.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides = make(map[string]Response)
+	s.queues = make(map[string][]Response)
+	s.requests = nil
+	s.subscriptions = nil
+	s.nonces = make(map[string]bool)
+	s.state = newState()
+}
+
+// register는 API 경로의 기본 처리 함수를 등록합니다.
+func (s *Server) register(method, path string, private bool, handle handlerFunc) {
+	s.routes[method+" "+path] = route{private: private, handle: handle}
+}
+
+// serveHTTP는 요청을 검증하고 설정된 응답 또는 기본 응답을 반환합니다.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, publicWebsocketPath) {
+		s.serveWebsocket(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, restPrefix+"/") {
+		writeResponse(w, ErrorResponse(http.StatusNotFound, "not_found", "Not Found"))
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, restPrefix)
+
+	// query_hash는 클라이언트가 보낸 문자열 그대로 계산해야 합니다.
+	rawQuery := r.URL.RawQuery
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeResponse(w, ErrorResponse(http.StatusBadRequest, rest.ErrValidationError, "failed to read body"))
+			return
+		}
+		rawQuery = string(body)
+	}
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		writeResponse(w, ErrorResponse(http.StatusBadRequest, rest.ErrInvalidParameter, err.Error()))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Method + " " + path
+	rt, ok := s.routes[key]
+	if !ok {
+		rt = route{private: true}
+	}
+
+	req := Request{Method: r.Method, Path: path, Params: params, Header: r.Header.Clone()}
+
+	var resp Response
+	if rt.private {
+		claims, errResp := s.verify(r.Header.Get("Authorization"), rawQuery)
+		req.Claims = claims
+		if errResp != nil {
+			resp = *errResp
+		}
+	}
+	s.requests = append(s.requests, req)
+
+	if resp.StatusCode == 0 {
+		resp = s.scripted(key, rt, params)
+	}
+
+	// 한도를 모르는 그룹은 잘못된 잔여 요청 수를 알려주지 않도록 헤더를 생략합니다.
+	group := s.limiter.Group(r.Method, path)
+	if limit, ok := groupLimits[group]; ok {
+		w.Header().Set(rest.RemainingReqHeader, fmt.Sprintf("group=%s; min=1800; sec=%d", group, limit-1))
+	}
+	writeResponse(w, resp)
+}
+
+// scripted는 잠금을 획득한 상태에서 요청에 반환할 응답을 결정합니다.
+func (s *Server) scripted(key string, rt route, params url.Values) Response {
+	if queue := s.queues[key]; len(queue) > 0 {
+		s.queues[key] = queue[1:]
+		return queue[0]
+	}
+	if resp, ok := s.overrides[key]; ok {
+		return resp
+	}
+	if rt.handle == nil {
+		return ErrorResponse(http.StatusNotFound, "not_found", "Not Found")
+	}
+	return rt.handle(params)
+}
+
+// verify는 잠금을 획득한 상태에서 Authorization 헤더의 JWT 토큰을 검증합니다.
+// 검증에 실패하면 Upbit API와 같은 형식의 에러 응답을 반환합니다.
+func (s *Server) verify(header, rawQuery string) (map[string]interface{}, *Response) {
+	fail := func(code rest.ErrorCode, message string) *Response {
+		resp := ErrorResponse(http.StatusUnauthorized, code, message)
+		return &resp
+	}
+
+	tokenString, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, fail(rest.ErrJWTVerification, "Jwt 토큰 검증에 실패했습니다.")
+	}
+
+	claims, err := auth.VerifyToken(tokenString, s.creds, rawQuery)
+	switch {
+	case errors.Is(err, auth.ErrInvalidToken):
+		return nil, fail(rest.ErrJWTVerification, "Jwt 토큰 검증에 실패했습니다.")
+	case errors.Is(err, auth.ErrAccessKeyMismatch):
+		return claims, fail(rest.ErrInvalidAccessKey, "잘못된 엑세스 키입니다.")
+	case errors.Is(err, auth.ErrMissingNonce):
+		return claims, fail(rest.ErrInvalidQueryPayload, "nonce가 없습니다.")
+	case errors.Is(err, auth.ErrQueryHashMismatch):
+		return claims, fail(rest.ErrInvalidQueryPayload, "query_hash가 요청과 일치하지 않습니다.")
+	case err != nil:
+		return claims, fail(rest.ErrInvalidQueryPayload, err.Error())
+	}
+
+	nonce, _ := claims["nonce"].(string)
+	if s.nonces[nonce] {
+		return claims, fail(rest.ErrNonceUsed, "이미 요청한 nonce값이 다시 들어왔습니다.")
+	}
+	s.nonces[nonce] = true
+
+	return claims, nil
+}
@@ -0,0 +1,304 @@
+package upbittest_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hysuki/go-upbit/auth"
+	"github.com/hysuki/go-upbit/decimal"
+	"github.com/hysuki/go-upbit/rest"
+	"github.com/hysuki/go-upbit/rest/exchange"
+	"github.com/hysuki/go-upbit/upbittest"
+)
+
+// balances는 계좌 목록을 화폐별 주문 가능 잔고와 묶인 잔고로 변환합니다.
+func balances(t *testing.T, ex *exchange.Exchange) map[string][2]string {
+	t.Helper()
+
+	accounts, err := ex.GetAccounts()
+	if err != nil {
+		t.Fatalf("GetAccounts error: %v", err)
+	}
+	result := make(map[string][2]string, len(accounts))
+	for _, a := range accounts {
+		result[a.Currency] = [2]string{a.Balance.String(), a.Locked.String()}
+	}
+	return result
+}
+
+func TestServerAccounts(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	ex := srv.Client().GetExchange()
+
+	got := balances(t, ex)
+	if got["KRW"] != [2]string{"1000000", "0"} || got["BTC"] != [2]string{"0.1", "0"} {
+		t.Fatalf("balances = %v", got)
+	}
+
+	srv.SetBalance("ETH", 2.5)
+	if got := balances(t, ex)["ETH"]; got != [2]string{"2.5", "0"} {
+		t.Errorf("ETH balance = %v, want 2.5", got)
+	}
+
+	// 요청은 인증 정보와 함께 기록됩니다.
+	reqs := srv.Requests()
+	if len(reqs) != 2 || reqs[0].Method != "GET" || reqs[0].Path != "/accounts" {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	if reqs[0].Claims["access_key"] != upbittest.DefaultAccessKey {
+		t.Errorf("claims = %v", reqs[0].Claims)
+	}
+}
+
+func TestServerOrderLifecycle(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	ex := srv.Client().GetExchange()
+
+	order, err := ex.CreateOrder(&exchange.CreateOrderRequest{
+		Market:    "KRW-BTC",
+		Side:      exchange.OrderSideBid,
+		OrderType: exchange.OrderTypeLimit,
+		Price:     "40000000",
+		Volume:    "0.01",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	if order.State != exchange.OrderStateWait {
+		t.Fatalf("state = %s, want wait", order.State)
+	}
+	if got := balances(t, ex)["KRW"]; got != [2]string{"600000", "400000"} {
+		t.Errorf("KRW after order = %v, want 600000 available and 400000 locked", got)
+	}
+
+	// 일부 체결된 주문을 취소하면 남은 금액만 돌려받습니다.
+	if !srv.PartialFillOrder(order.UUID, 0.004) {
+		t.Fatal("PartialFillOrder failed")
+	}
+	cancelled, err := ex.CancelOrder(&exchange.CancelOrderParams{UUID: order.UUID})
+	if err != nil {
+		t.Fatalf("CancelOrder error: %v", err)
+	}
+	if cancelled.State != exchange.OrderStateCancel || !cancelled.ExecutedVolume.Equal(decimal.RequireFromString("0.004")) {
+		t.Errorf("cancelled = %s executed %s, want cancel executed 0.004", cancelled.State, cancelled.ExecutedVolume)
+	}
+	got := balances(t, ex)
+	if got["KRW"] != [2]string{"840000", "0"} || got["BTC"] != [2]string{"0.104", "0"} {
+		t.Errorf("balances after cancel = %v", got)
+	}
+
+	// 대기 상태가 아닌 주문은 다시 취소하거나 체결할 수 없습니다.
+	if _, err := ex.CancelOrder(&exchange.CancelOrderParams{UUID: order.UUID}); !errors.Is(err, rest.ErrOrderNotFound) {
+		t.Errorf("second CancelOrder error = %v, want order_not_found", err)
+	}
+	if srv.FillOrder(order.UUID) {
+		t.Error("FillOrder succeeded on a cancelled order")
+	}
+
+	// 잔고가 부족하거나 최소 주문 금액 미만이면 거부됩니다.
+	_, err = ex.CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypeLimit, Price: "50000000", Volume: "1"})
+	if !errors.Is(err, rest.ErrInsufficientFundsBid) {
+		t.Errorf("CreateOrder over balance error = %v, want insufficient_funds_bid", err)
+	}
+	_, err = ex.CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-XRP", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypeLimit, Price: "700", Volume: "1"})
+	if !errors.Is(err, rest.ErrUnderMinTotalBid) {
+		t.Errorf("CreateOrder under min total error = %v, want under_min_total_bid", err)
+	}
+
+	// 지정가 주문은 FillOrder로 모두 체결됩니다.
+	order, err = ex.CreateOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideAsk, OrderType: exchange.OrderTypeLimit, Price: "55000000", Volume: "0.004"})
+	if err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	if !srv.FillOrder(order.UUID) {
+		t.Fatal("FillOrder failed")
+	}
+	done, err := ex.GetOrder(&exchange.GetOrderParams{UUID: order.UUID})
+	if err != nil {
+		t.Fatalf("GetOrder error: %v", err)
+	}
+	if done.State != exchange.OrderStateDone || len(done.Trades) != 1 {
+		t.Errorf("filled order = %s with %d trades, want done with 1", done.State, len(done.Trades))
+	}
+	if got := balances(t, ex)["KRW"]; got != [2]string{"1060000", "0"} {
+		t.Errorf("KRW after fill = %v, want 1060000", got)
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	// tamper는 서명이 끝난 요청의 쿼리 문자열을 바꾸는 미들웨어입니다.
+	tamper := func(next rest.RoundTrip) rest.RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			req.URL.RawQuery = url.Values{"market": {"KRW-ETH"}}.Encode()
+			return next(req)
+		}
+	}
+	fixedNonce := auth.WithNonceFunc(func() string { return "fixed-nonce" })
+
+	tests := []struct {
+		name    string
+		client  rest.Client
+		calls   int // 같은 요청을 보내는 횟수 (마지막 요청의 에러를 확인)
+		wantErr error
+	}{
+		{
+			name:   "정상",
+			client: srv.Client(),
+			calls:  2,
+		},
+		{
+			name:    "변조된 쿼리",
+			client:  srv.Client(rest.WithMiddleware(tamper)),
+			calls:   1,
+			wantErr: rest.ErrInvalidQueryPayload,
+		},
+		{
+			name:    "재사용한 nonce",
+			client:  rest.NewClient(auth.NewRestTokenGen(srv.Credentials(), fixedNonce), rest.WithBaseURL(srv.BaseURL())),
+			calls:   2,
+			wantErr: rest.ErrNonceUsed,
+		},
+		{
+			name:    "잘못된 시크릿 키",
+			client:  rest.NewClient(auth.NewRestTokenGen(auth.Credentials{AccessKey: upbittest.DefaultAccessKey, SecretKey: "wrong"}), rest.WithBaseURL(srv.BaseURL())),
+			calls:   1,
+			wantErr: rest.ErrJWTVerification,
+		},
+		{
+			name:    "잘못된 액세스 키",
+			client:  rest.NewClient(auth.NewRestTokenGen(auth.Credentials{AccessKey: "other", SecretKey: upbittest.DefaultSecretKey}), rest.WithBaseURL(srv.BaseURL())),
+			calls:   1,
+			wantErr: rest.ErrInvalidAccessKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.Reset()
+
+			for i := 1; i < tt.calls; i++ {
+				if _, err := tt.client.GetExchange().GetOrderChance("KRW-BTC"); err != nil {
+					t.Fatalf("request %d error: %v", i, err)
+				}
+			}
+			_, err := tt.client.GetExchange().GetOrderChance("KRW-BTC")
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("GetOrderChance error: %v", err)
+				}
+				return
+			}
+
+			var apiErr *rest.APIError
+			if !errors.As(err, &apiErr) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetOrderChance error = %v, want %s", err, tt.wantErr)
+			}
+			if apiErr.StatusCode != http.StatusUnauthorized || !errors.Is(err, rest.ErrAuthentication) {
+				t.Errorf("status = %d, want 401 authentication error", apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServerScriptedResponses(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	ex := srv.Client().GetExchange()
+
+	chance := func() error {
+		_, err := ex.GetOrderChance("KRW-BTC")
+		return err
+	}
+
+	// Enqueue한 응답을 순서대로 한 번씩 반환한 뒤 Handle로 지정한 응답을 반환합니다.
+	srv.Handle("GET", "/orders/chance", upbittest.ErrorResponse(http.StatusBadRequest, rest.ErrValidationError, "handled"))
+	srv.Enqueue("GET", "/orders/chance",
+		upbittest.ErrorResponse(http.StatusNotFound, rest.ErrOrderNotFound, "first"),
+		upbittest.ErrorResponse(http.StatusBadRequest, rest.ErrInvalidParameter, "second"),
+	)
+	want := []error{rest.ErrOrderNotFound, rest.ErrInvalidParameter, rest.ErrValidationError, rest.ErrValidationError}
+	for i, w := range want {
+		if err := chance(); !errors.Is(err, w) {
+			t.Fatalf("request %d error = %v, want %s", i+1, err, w)
+		}
+	}
+
+	// 설정한 응답도 인증 검증을 거칩니다.
+	bad := rest.NewClient(auth.NewRestTokenGen(auth.Credentials{AccessKey: upbittest.DefaultAccessKey, SecretKey: "wrong"}), rest.WithBaseURL(srv.BaseURL()))
+	if _, err := bad.GetExchange().GetOrderChance("KRW-BTC"); !errors.Is(err, rest.ErrJWTVerification) {
+		t.Errorf("scripted response with a bad token error = %v, want jwt_verification", err)
+	}
+
+	// Reset하면 기본 응답으로 돌아갑니다.
+	srv.Reset()
+	if err := chance(); err != nil {
+		t.Fatalf("GetOrderChance after Reset error: %v", err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("requests after Reset = %d, want 1", n)
+	}
+}
+
+func TestServerRemainingReq(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if _, err := client.GetExchange().GetAccounts(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetQuotation().GetTicker([]string{"KRW-BTC"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetExchange().TestOrder(&exchange.CreateOrderRequest{Market: "KRW-BTC", Side: exchange.OrderSideBid, OrderType: exchange.OrderTypeLimit, Price: "40000000", Volume: "0.001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := client.RateLimiter().Snapshot()
+	for group, want := range map[string]int{rest.GroupDefault: 29, rest.GroupTicker: 9, rest.GroupOrderTest: 7} {
+		if rr, ok := snapshot[group]; !ok || rr.Sec != want {
+			t.Errorf("%s RemainingReq = %+v, %v, want sec=%d", group, rr, ok, want)
+		}
+	}
+}
+
+func TestServerQuotation(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	q := srv.Client().GetQuotation()
+
+	markets, err := q.GetMarkets(false)
+	if err != nil {
+		t.Fatalf("GetMarkets error: %v", err)
+	}
+	if len(markets) != 4 || markets[0].Market != "KRW-BTC" {
+		t.Errorf("markets = %+v", markets)
+	}
+
+	srv.SetPrice("KRW-BTC", 51000000)
+	srv.SetPrice("KRW-DOGE", 200)
+	tickers, err := q.GetTicker([]string{"KRW-BTC", "KRW-DOGE"})
+	if err != nil {
+		t.Fatalf("GetTicker error: %v", err)
+	}
+	if len(tickers) != 2 || tickers[0].TradePrice.String() != "51000000" || tickers[1].TradePrice.String() != "200" {
+		t.Errorf("tickers = %+v", tickers)
+	}
+
+	// 시세 조회 API는 인증 없이 호출됩니다.
+	reqs := srv.Requests()
+	if reqs[len(reqs)-1].Claims != nil || reqs[len(reqs)-1].Header.Get("Authorization") != "" {
+		t.Errorf("quotation request carried credentials: %+v", reqs[len(reqs)-1])
+	}
+
+	if _, err := q.GetTicker([]string{"KRW-NONE"}); err == nil {
+		t.Error("GetTicker for an unknown market succeeded")
+	}
+}
@@ -0,0 +1,295 @@
+package upbittest
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// kst는 Upbit API가 거래소 API 응답 시각에 사용하는 시간대입니다.
+var kst = time.FixedZone("KST", 9*60*60)
+
+// minTotals는 마켓 기준 화폐별 최소 주문 금액입니다.
+var minTotals = map[string]float64{
+	"KRW":  5000,
+	"BTC":  0.00005,
+	"USDT": 0.5,
+}
+
+// market은 모의 서버의 마켓 정보입니다.
+type market struct {
+	code        string  // 마켓 코드 (예: KRW-BTC)
+	koreanName  string  // 한글명
+	englishName string  // 영문명
+	price       float64 // 현재가
+	warning     bool    // 유의 종목 지정 여부
+}
+
+// account는 화폐별 잔고입니다.
+type account struct {
+	balance     float64
+	locked      float64
+	avgBuyPrice float64
+}
+
+// order는 모의 서버에 생성된 주문입니다.
+type order struct {
+	uuid           string
+	identifier     string
+	market         string
+	side           string
+	ordType        string
+	price          float64
+	volume         float64
+	executedVolume float64
+	locked         float64
+	state          string
+	timeInForce    string
+	createdAt      time.Time
+	trades         []*orderTrade
+}
+
+// orderTrade는 주문의 체결 내역입니다.
+type orderTrade struct {
+	uuid      string
+	price     float64
+	volume    float64
+	createdAt time.Time
+}
+
+// executedFunds는 주문의 체결 내역을 합산한 체결 금액입니다.
+func (o *order) executedFunds() float64 {
+	var funds float64
+	for _, t := range o.trades {
+		funds += t.price * t.volume
+	}
+	return funds
+}
+
+// transfer는 입금 또는 출금 기록입니다.
+type transfer struct {
+	Type            string  `json:"type"`
+	UUID            string  `json:"uuid"`
+	Currency        string  `json:"currency"`
+	NetType         string  `json:"net_type,omitempty"`
+	TxID            string  `json:"txid"`
+	State           string  `json:"state"`
+	CreatedAt       string  `json:"created_at"`
+	DoneAt          *string `json:"done_at"`
+	Amount          string  `json:"amount"`
+	Fee             string  `json:"fee"`
+	KrwAmount       string  `json:"krw_amount,omitempty"`
+	TransactionType string  `json:"transaction_type"`
+}
+
+// coinAddress는 입금 또는 출금 주소입니다.
+type coinAddress struct {
+	Currency         string
+	NetType          string
+	Address          string
+	SecondaryAddress *string
+}
+
+// state는 모의 서버의 계좌, 주문, 입출금 상태입니다.
+type state struct {
+	markets           []*market
+	accounts          map[string]*account
+	orders            []*order
+	deposits          []*transfer
+	withdraws         []*transfer
+	depositAddresses  []*coinAddress
+	withdrawAddresses []*coinAddress
+}
+
+// newState는 기본 마켓과 잔고를 가진 상태를 생성합니다.
+func newState() *state {
+	return &state{
+		markets: []*market{
+			{code: "KRW-BTC", koreanName: "비트코인", englishName: "Bitcoin", price: 50000000},
+			{code: "KRW-ETH", koreanName: "이더리움", englishName: "Ethereum", price: 3000000},
+			{code: "KRW-XRP", koreanName: "리플", englishName: "Ripple", price: 700},
+			{code: "BTC-ETH", koreanName: "이더리움", englishName: "Ethereum", price: 0.06},
+		},
+		accounts: map[string]*account{
+			"KRW": {balance: 1000000},
+			"BTC": {balance: 0.1, avgBuyPrice: 45000000},
+		},
+		withdrawAddresses: []*coinAddress{
+			{Currency: "BTC", NetType: "BTC", Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		},
+	}
+}
+
+// market은 마켓 코드에 해당하는 마켓을 반환합니다.
+func (st *state) market(code string) *market {
+	for _, m := range st.markets {
+		if m.code == code {
+			return m
+		}
+	}
+	return nil
+}
+
+// account는 화폐의 잔고를 반환하며, 없으면 생성합니다.
+func (st *state) account(currency string) *account {
+	a, ok := st.accounts[currency]
+	if !ok {
+		a = &account{}
+		st.accounts[currency] = a
+	}
+	return a
+}
+
+// findOrder는 uuid 또는 identifier에 해당하는 주문을 반환합니다.
+func (st *state) findOrder(uuid, identifier string) *order {
+	for _, o := range st.orders {
+		if (uuid != "" && o.uuid == uuid) || (identifier != "" && o.identifier == identifier) {
+			return o
+		}
+	}
+	return nil
+}
+
+// SetBalance는 화폐의 주문 가능 잔고를 설정합니다.
+func (s *Server) SetBalance(currency string, balance float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.account(currency).balance = balance
+}
+
+// SetPrice는 마켓의 현재가를 설정합니다. 없는 마켓이면 새로 추가합니다.
+// 시세 조회 API와 시장가 주문의 체결 가격에 사용됩니다.
+func (s *Server) SetPrice(code string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m := s.state.market(code); m != nil {
+		m.price = price
+		return
+	}
+	s.state.markets = append(s.state.markets, &market{code: code, koreanName: code, englishName: code, price: price})
+}
+
+// FillOrder는 대기 중인 주문을 주문 가격으로 모두 체결시킵니다.
+// 주문이 없거나 대기 상태가 아니면 false를 반환합니다.
+func (s *Server) FillOrder(uuid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.state.findOrder(uuid, "")
+	if o == nil || o.state != "wait" {
+		return false
+	}
+	s.state.fill(o, o.price)
+	s.publishOrder(o)
+	return true
+}
+
+// PartialFillOrder는 대기 중인 지정가 주문을 주문 가격으로 volume만큼 체결시킵니다.
+// 누적 체결량이 주문량에 도달하면 주문이 완료됩니다.
+// 주문이 없거나 대기 상태의 지정가 주문이 아니거나 volume이 잔여 주문량보다 크면 false를 반환합니다.
+func (s *Server) PartialFillOrder(uuid string, volume float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.state.findOrder(uuid, "")
+	if o == nil || o.state != "wait" || o.ordType != "limit" {
+		return false
+	}
+	if volume <= 0 || volume > o.volume-o.executedVolume {
+		return false
+	}
+	s.state.execute(o, volume, o.price)
+	s.publishOrder(o)
+	return true
+}
+
+// fill은 주문의 잔여 수량을 price로 모두 체결하고 잔고에 반영합니다.
+func (st *state) fill(o *order, price float64) {
+	if o.ordType == "price" {
+		// 시장가 매수는 주문 금액을 현재가로 나눈 수량만큼 체결됩니다.
+		o.volume = o.price / price
+	}
+	st.execute(o, o.volume-o.executedVolume, price)
+}
+
+// execute는 주문을 price로 volume만큼 체결하고 체결 내역과 잔고에 반영합니다.
+// 누적 체결량이 주문량에 도달하면 남은 묶인 잔고를 풀고 주문을 완료합니다.
+func (st *state) execute(o *order, volume, price float64) {
+	quote, base := splitMarket(o.market)
+	funds := volume * price
+
+	final := o.executedVolume+volume >= o.volume
+	released := o.locked
+	if !final {
+		released = volume
+		if o.side == "bid" {
+			released = volume * o.price
+		}
+	}
+
+	if o.side == "bid" {
+		q := st.account(quote)
+		q.locked -= released
+		q.balance += released - funds
+		b := st.account(base)
+		if total := b.balance + b.locked + volume; total > 0 {
+			b.avgBuyPrice = (b.avgBuyPrice*(b.balance+b.locked) + price*volume) / total
+		}
+		b.balance += volume
+	} else {
+		st.account(base).locked -= released
+		st.account(quote).balance += funds
+	}
+
+	o.trades = append(o.trades, &orderTrade{
+		uuid:      uuid.NewString(),
+		price:     price,
+		volume:    volume,
+		createdAt: time.Now(),
+	})
+	o.executedVolume += volume
+	o.locked -= released
+	if final {
+		o.executedVolume = o.volume
+		o.locked = 0
+		o.state = "done"
+	}
+}
+
+// cancel은 대기 중인 주문을 취소하고 묶인 잔고를 돌려줍니다.
+func (st *state) cancel(o *order) {
+	quote, base := splitMarket(o.market)
+	currency := base
+	if o.side == "bid" {
+		currency = quote
+	}
+	a := st.account(currency)
+	a.locked -= o.locked
+	a.balance += o.locked
+
+	o.locked = 0
+	o.state = "cancel"
+}
+
+// splitMarket은 "KRW-BTC" 형식의 마켓 코드를 기준 화폐와 거래 화폐로 나눕니다.
+func splitMarket(code string) (quote, base string) {
+	quote, base, _ = strings.Cut(code, "-")
+	return quote, base
+}
+
+// formatNumber는 실수를 소수점 8자리까지의 문자열로 변환합니다.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e8)/1e8, 'f', -1, 64)
+}
+
+// parseNumber는 문자열을 실수로 변환합니다. 형식이 잘못되면 false를 반환합니다.
+func parseNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil && f >= 0
+}
+
+// formatTime은 거래소 API 응답 형식(KST, RFC 3339)으로 시각을 변환합니다.
+func formatTime(t time.Time) string {
+	return t.In(kst).Format(time.RFC3339)
+}
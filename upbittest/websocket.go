@@ -0,0 +1,497 @@
+package upbittest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+)
+
+// 웹소켓 API 경로입니다.
+const (
+	publicWebsocketPath  = "/websocket/v1"
+	privateWebsocketPath = "/websocket/v1/private"
+)
+
+// 웹소켓 메시지 타입입니다.
+var (
+	publicTypes  = []string{"ticker", "trade", "orderbook"}
+	privateTypes = []string{"myOrder", "myAsset"}
+)
+
+// SubscriptionType은 구독 요청에 포함된 타입 하나입니다.
+type SubscriptionType struct {
+	Type           string   `json:"type"`                       // 메시지 타입
+	Codes          []string `json:"codes,omitempty"`            // 마켓 코드 목록
+	Level          *float64 `json:"level,omitempty"`            // 호가 모아보기 단위
+	IsOnlySnapshot *bool    `json:"is_only_snapshot,omitempty"` // 스냅샷 시세만 제공
+	IsOnlyRealtime *bool    `json:"is_only_realtime,omitempty"` // 실시간 시세만 제공
+}
+
+// Subscription은 웹소켓 클라이언트가 보낸 구독 요청입니다.
+type Subscription struct {
+	Private bool               // 비공개 웹소켓 여부
+	Ticket  string             // 식별용 티켓
+	Format  string             // 응답 형식 (지정하지 않으면 빈 문자열)
+	Types   []SubscriptionType // 구독 타입 목록
+}
+
+// wsConn은 모의 서버에 연결된 웹소켓 클라이언트입니다.
+type wsConn struct {
+	conn    *websocket.Conn
+	private bool
+	types   []SubscriptionType // 현재 구독 중인 타입 (새 구독 요청이 오면 교체)
+	send    chan []byte        // 전송 대기 중인 메시지
+}
+
+// PublicWebsocketURL은 공개 웹소켓 API 주소를 반환합니다.
+func (s *Server) PublicWebsocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + publicWebsocketPath
+}
+
+// PrivateWebsocketURL은 비공개 웹소켓 API 주소를 반환합니다.
+func (s *Server) PrivateWebsocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + privateWebsocketPath
+}
+
+// Publish는 msgType과 code를 구독 중인 모든 연결에 실시간(REALTIME) 메시지를 전송합니다.
+// 메시지는 모의 서버의 시세로 채워지며, fields로 지정한 필드가 우선합니다.
+// myAsset처럼 마켓 코드가 없는 타입은 code를 빈 문자열로 지정합니다.
+//
+//	srv.Publish("ticker", "KRW-BTC", map[string]interface{}{"trade_price": 51000000})
+func (s *Server) Publish(msgType, code string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publish(msgType, code, fields)
+}
+
+// publish는 잠금을 획득한 상태에서 msgType과 code를 구독 중인 연결에 메시지를 전송합니다.
+func (s *Server) publish(msgType, code string, fields map[string]interface{}) {
+	var data []byte
+	for c := range s.conns {
+		if !c.subscribed(msgType, code) {
+			continue
+		}
+		if data == nil {
+			data = s.frame(msgType, code, "REALTIME", fields)
+		}
+		c.enqueue(data)
+	}
+}
+
+// publishOrder는 잠금을 획득한 상태에서 REST API로 바뀐 주문과 자산 정보를
+// myOrder, myAsset 구독자에게 전송합니다.
+func (s *Server) publishOrder(o *order) {
+	askBid := "BID"
+	if o.side == "ask" {
+		askBid = "ASK"
+	}
+	avgPrice := 0.0
+	if o.executedVolume > 0 {
+		avgPrice = o.executedFunds() / o.executedVolume
+	}
+
+	fields := map[string]interface{}{
+		"uuid":             o.uuid,
+		"ask_bid":          askBid,
+		"order_type":       o.ordType,
+		"state":            o.state,
+		"price":            o.price,
+		"avg_price":        avgPrice,
+		"volume":           o.volume,
+		"remaining_volume": o.volume - o.executedVolume,
+		"executed_volume":  o.executedVolume,
+		"locked":           o.locked,
+		"executed_funds":   o.executedFunds(),
+		"order_timestamp":  o.createdAt.UnixMilli(),
+	}
+	if o.executedVolume > 0 {
+		fields["state"] = "trade"
+		last := o.trades[len(o.trades)-1]
+		fields["trades_count"] = len(o.trades)
+		fields["trade_uuid"] = last.uuid
+		fields["trade_timestamp"] = last.createdAt.UnixMilli()
+	}
+	if o.timeInForce != "" {
+		fields["time_in_force"] = o.timeInForce
+	}
+
+	s.publish("myOrder", o.market, fields)
+	if o.state == "done" {
+		fields["state"] = "done"
+		s.publish("myOrder", o.market, fields)
+	}
+	s.publish("myAsset", "", nil)
+}
+
+// Broadcast는 구독 여부와 관계없이 모든 연결에 data를 그대로 전송합니다.
+func (s *Server) Broadcast(data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.conns {
+		c.enqueue([]byte(data))
+	}
+}
+
+// SendStatus는 모든 연결에 서버 상태 메시지({"status":"UP"} 또는 {"status":"DOWN"})를 전송합니다.
+func (s *Server) SendStatus(status string) {
+	data, _ := json.Marshal(map[string]string{"status": status})
+	s.Broadcast(string(data))
+}
+
+// DropConnections는 모든 웹소켓 연결을 종료 핸드셰이크 없이 끊습니다.
+// 클라이언트의 재연결과 재구독 동작을 테스트할 때 사용합니다.
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	conns := make([]*wsConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.conn.CloseNow()
+	}
+}
+
+// Connections는 현재 연결된 웹소켓 클라이언트 수를 반환합니다.
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Subscriptions는 지금까지 수신한 구독 요청 목록을 반환합니다.
+func (s *Server) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Subscription(nil), s.subscriptions...)
+}
+
+// WaitSubscriptions는 수신한 구독 요청이 n개 이상이 될 때까지 기다린 뒤 목록을 반환합니다.
+// ctx가 종료되면 ctx의 에러를 반환합니다.
+func (s *Server) WaitSubscriptions(ctx context.Context, n int) ([]Subscription, error) {
+	for {
+		s.mu.Lock()
+		if len(s.subscriptions) >= n {
+			subs := append([]Subscription(nil), s.subscriptions...)
+			s.mu.Unlock()
+			return subs, nil
+		}
+		changed := s.subscribed
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// serveWebsocket은 웹소켓 연결을 수락하고 구독 요청을 처리합니다.
+// 비공개 웹소켓은 연결 전에 Authorization 헤더의 토큰을 검증합니다.
+func (s *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	private := r.URL.Path == privateWebsocketPath
+	if !private && r.URL.Path != publicWebsocketPath {
+		writeResponse(w, ErrorResponse(http.StatusNotFound, "not_found", "Not Found"))
+		return
+	}
+
+	if private {
+		s.mu.Lock()
+		_, errResp := s.verify(r.Header.Get("Authorization"), "")
+		s.mu.Unlock()
+		if errResp != nil {
+			writeResponse(w, *errResp)
+			return
+		}
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		CompressionMode: websocket.CompressionContextTakeover,
+	})
+	if err != nil {
+		return
+	}
+
+	c := &wsConn{conn: conn, private: private, send: make(chan []byte, 1000)}
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer func() {
+		cancel()
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		conn.CloseNow()
+	}()
+
+	go c.writeLoop(ctx)
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		// Upbit 서버는 "PING" 텍스트 메시지에 상태 메시지로 응답합니다.
+		if strings.TrimSpace(string(data)) == "PING" {
+			c.enqueue([]byte(`{"status":"UP"}`))
+			continue
+		}
+
+		sub, errFrame := parseSubscription(data, private)
+		if errFrame != nil {
+			c.enqueue(errFrame)
+			continue
+		}
+
+		s.mu.Lock()
+		c.types = sub.Types
+		s.subscriptions = append(s.subscriptions, sub)
+		close(s.subscribed)
+		s.subscribed = make(chan struct{})
+		s.sendSnapshots(c)
+		s.mu.Unlock()
+	}
+}
+
+// sendSnapshots는 잠금을 획득한 상태에서 새로 구독한 공개 타입의 스냅샷 메시지를 전송합니다.
+// is_only_realtime이 지정된 타입은 스냅샷을 보내지 않습니다.
+func (s *Server) sendSnapshots(c *wsConn) {
+	for _, t := range c.types {
+		if !contains(publicTypes, t.Type) || (t.IsOnlyRealtime != nil && *t.IsOnlyRealtime) {
+			continue
+		}
+		for _, code := range t.Codes {
+			if s.state.market(code) == nil {
+				continue
+			}
+			c.enqueue(s.frame(t.Type, code, "SNAPSHOT", nil))
+		}
+	}
+}
+
+// writeLoop는 전송 대기 중인 메시지를 순서대로 전송합니다.
+func (c *wsConn) writeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-c.send:
+			if err := c.conn.Write(ctx, websocket.MessageText, data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// enqueue는 메시지를 전송 대기열에 추가합니다. 대기열이 가득 차면 메시지를 버립니다.
+func (c *wsConn) enqueue(data []byte) {
+	select {
+	case c.send <- data:
+	default:
+	}
+}
+
+// subscribed는 msgType과 code를 구독 중인지 확인합니다.
+// 마켓 코드 없이 구독한 타입은 모든 코드의 메시지를 받습니다.
+func (c *wsConn) subscribed(msgType, code string) bool {
+	for _, t := range c.types {
+		if t.Type == msgType && (len(t.Codes) == 0 || code == "" || contains(t.Codes, code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// wsError는 Upbit 웹소켓 에러 메시지를 생성합니다.
+func wsError(name, message string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]string{"name": name, "message": message},
+	})
+	return data
+}
+
+// parseSubscription은 [{"ticket":...},{"type":...,"codes":[...]},{"format":...}] 형식의
+// 구독 요청을 파싱합니다. 형식이 잘못되면 Upbit와 같은 형식의 에러 메시지를 반환합니다.
+func parseSubscription(data []byte, private bool) (Subscription, []byte) {
+	sub := Subscription{Private: private}
+
+	var fields []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return sub, wsError("WRONG_FORMAT", "요청 형식이 잘못되었습니다.")
+	}
+
+	allowed := publicTypes
+	if private {
+		allowed = privateTypes
+	}
+
+	for _, field := range fields {
+		switch {
+		case field["ticket"] != nil:
+			if err := json.Unmarshal(field["ticket"], &sub.Ticket); err != nil {
+				return sub, wsError("WRONG_FORMAT", "ticket 형식이 잘못되었습니다.")
+			}
+		case field["format"] != nil:
+			if err := json.Unmarshal(field["format"], &sub.Format); err != nil {
+				return sub, wsError("WRONG_FORMAT", "format 형식이 잘못되었습니다.")
+			}
+		case field["type"] != nil:
+			raw, _ := json.Marshal(field)
+			var t SubscriptionType
+			if err := json.Unmarshal(raw, &t); err != nil {
+				return sub, wsError("WRONG_FORMAT", "type 형식이 잘못되었습니다.")
+			}
+			if !contains(allowed, t.Type) {
+				return sub, wsError("INVALID_PARAM", "지원하지 않는 타입입니다: "+t.Type)
+			}
+			if t.Type != "myAsset" && t.Type != "myOrder" && len(t.Codes) == 0 {
+				return sub, wsError("NO_CODES", "codes가 없습니다.")
+			}
+			sub.Types = append(sub.Types, t)
+		}
+	}
+
+	if sub.Ticket == "" {
+		return sub, wsError("NO_TICKET", "ticket이 없습니다.")
+	}
+	if len(sub.Types) == 0 {
+		return sub, wsError("NO_TYPE", "type이 없습니다.")
+	}
+	return sub, nil
+}
+
+// frame은 잠금을 획득한 상태에서 메시지 타입별 기본 필드로 웹소켓 메시지를 생성합니다.
+// fields로 지정한 필드가 기본값보다 우선합니다.
+func (s *Server) frame(msgType, code, streamType string, fields map[string]interface{}) []byte {
+	price := 0.0
+	if m := s.state.market(code); m != nil {
+		price = m.price
+	}
+
+	now := time.Now()
+	utc := now.UTC()
+	v := map[string]interface{}{
+		"type":        msgType,
+		"timestamp":   now.UnixMilli(),
+		"stream_type": streamType,
+	}
+	if code != "" {
+		v["code"] = code
+	}
+
+	switch msgType {
+	case "ticker":
+		for key, value := range map[string]interface{}{
+			"opening_price":         price,
+			"high_price":            price,
+			"low_price":             price,
+			"trade_price":           price,
+			"prev_closing_price":    price,
+			"change":                "EVEN",
+			"change_price":          0,
+			"signed_change_price":   0,
+			"change_rate":           0,
+			"signed_change_rate":    0,
+			"trade_volume":          1,
+			"acc_trade_volume":      100,
+			"acc_trade_volume_24h":  100,
+			"acc_trade_price":       price * 100,
+			"acc_trade_price_24h":   price * 100,
+			"trade_date":            utc.Format("20060102"),
+			"trade_time":            utc.Format("150405"),
+			"trade_timestamp":       now.UnixMilli(),
+			"ask_bid":               "BID",
+			"acc_ask_volume":        50,
+			"acc_bid_volume":        50,
+			"highest_52_week_price": price,
+			"highest_52_week_date":  utc.Format(time.DateOnly),
+			"lowest_52_week_price":  price,
+			"lowest_52_week_date":   utc.Format(time.DateOnly),
+			"market_state":          "ACTIVE",
+			"market_warning":        "NONE",
+		} {
+			v[key] = value
+		}
+	case "trade":
+		for key, value := range map[string]interface{}{
+			"trade_price":        price,
+			"trade_volume":       1,
+			"ask_bid":            "BID",
+			"prev_closing_price": price,
+			"change":             "EVEN",
+			"change_price":       0,
+			"trade_date":         utc.Format(time.DateOnly),
+			"trade_time":         utc.Format(time.TimeOnly),
+			"trade_timestamp":    now.UnixMilli(),
+			"sequential_id":      now.UnixMicro(),
+		} {
+			v[key] = value
+		}
+	case "orderbook":
+		tick := price * 0.001
+		units := make([]map[string]float64, 0, 15)
+		for i := 1; i <= 15; i++ {
+			units = append(units, map[string]float64{
+				"ask_price": price + tick*float64(i),
+				"bid_price": price - tick*float64(i),
+				"ask_size":  1,
+				"bid_size":  1,
+			})
+		}
+		v["total_ask_size"] = 15
+		v["total_bid_size"] = 15
+		v["orderbook_units"] = units
+		v["level"] = 0
+	case "myOrder":
+		for key, value := range map[string]interface{}{
+			"uuid":             uuid.NewString(),
+			"ask_bid":          "BID",
+			"order_type":       "limit",
+			"state":            "wait",
+			"price":            price,
+			"avg_price":        0,
+			"volume":           0,
+			"remaining_volume": 0,
+			"executed_volume":  0,
+			"trades_count":     0,
+			"reserved_fee":     0,
+			"remaining_fee":    0,
+			"paid_fee":         0,
+			"locked":           0,
+			"executed_funds":   0,
+			"order_timestamp":  now.UnixMilli(),
+		} {
+			v[key] = value
+		}
+	case "myAsset":
+		assets := make([]map[string]interface{}, 0, len(s.state.accounts))
+		for currency, a := range s.state.accounts {
+			assets = append(assets, map[string]interface{}{
+				"currency": currency,
+				"balance":  a.balance,
+				"locked":   a.locked,
+			})
+		}
+		v["asset_uuid"] = uuid.NewString()
+		v["assets"] = assets
+		v["asset_timestamp"] = now.UnixMilli()
+	}
+
+	for key, value := range fields {
+		v[key] = value
+	}
+
+	data, _ := json.Marshal(v)
+	return data
+}
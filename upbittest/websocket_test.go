@@ -0,0 +1,266 @@
+package upbittest_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/hysuki/go-upbit/auth"
+	"github.com/hysuki/go-upbit/rest/exchange"
+	"github.com/hysuki/go-upbit/upbittest"
+	"github.com/hysuki/go-upbit/websocket/common"
+	"github.com/hysuki/go-upbit/websocket/private"
+	"github.com/hysuki/go-upbit/websocket/public"
+)
+
+// receive는 get이 반환할 때까지 기다립니다. 제한 시간 안에 메시지가 오지 않으면 테스트를 중단합니다.
+func receive[T any](t *testing.T, get func() (T, error)) T {
+	t.Helper()
+
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := get()
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("receive error: %v", r.err)
+		}
+		return r.v
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a message")
+	}
+	panic("unreachable")
+}
+
+// waitSubscriptions는 구독 요청이 n개 이상 도착할 때까지 기다립니다.
+func waitSubscriptions(t *testing.T, srv *upbittest.Server, n int) []upbittest.Subscription {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	subs, err := srv.WaitSubscriptions(ctx, n)
+	if err != nil {
+		t.Fatalf("WaitSubscriptions(%d) error: %v", n, err)
+	}
+	return subs
+}
+
+func TestWebsocketPublic(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	client, err := public.NewClient(srv.PublicWebsocketURL(), nil, 0)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	defer client.Close()
+	defer client.Stop()
+
+	realtime := true
+	ticket := "public-test"
+	err = client.Subscribe(&ticket,
+		public.AddSubscribe(public.MessageTypeTicker, []string{"krw-btc"}, nil),
+		public.AddSubscribe(public.MessageTypeOrderbook, []string{"KRW-ETH"}, &common.SubscribeOptions{IsOnlyRealtime: &realtime}),
+	)
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+
+	// 구독 요청의 티켓과 타입 배열이 그대로 전달됩니다.
+	sub := waitSubscriptions(t, srv, 1)[0]
+	if sub.Private || sub.Ticket != ticket || len(sub.Types) != 2 {
+		t.Fatalf("subscription = %+v", sub)
+	}
+	if typ := sub.Types[0]; typ.Type != "ticker" || !slices.Equal(typ.Codes, []string{"KRW-BTC"}) || typ.IsOnlyRealtime != nil {
+		t.Errorf("types[0] = %+v, want ticker KRW-BTC", typ)
+	}
+	if typ := sub.Types[1]; typ.Type != "orderbook" || !slices.Equal(typ.Codes, []string{"KRW-ETH"}) || typ.IsOnlyRealtime == nil || !*typ.IsOnlyRealtime {
+		t.Errorf("types[1] = %+v, want realtime-only orderbook KRW-ETH", typ)
+	}
+
+	client.StartMessageHandler()
+
+	// 구독 직후에는 모의 서버의 시세로 스냅샷을 받습니다.
+	ticker := receive(t, func() (*public.Ticker, error) { return client.GetTicker(time.UTC) })
+	if ticker.Code != "KRW-BTC" || ticker.StreamType != common.StreamTypeSnapshot || ticker.TradePrice.String() != "50000000" {
+		t.Errorf("snapshot = %s %s %s, want KRW-BTC SNAPSHOT 50000000", ticker.Code, ticker.StreamType, ticker.TradePrice)
+	}
+
+	srv.Publish("ticker", "KRW-BTC", map[string]interface{}{"trade_price": 51000000, "change": "RISE"})
+	ticker = receive(t, func() (*public.Ticker, error) { return client.GetTicker(time.UTC) })
+	if ticker.StreamType != common.StreamTypeRealtime || ticker.TradePrice.String() != "51000000" || ticker.Change != common.ChangeTypeRise {
+		t.Errorf("realtime = %s %s %s, want REALTIME 51000000 RISE", ticker.StreamType, ticker.TradePrice, ticker.Change)
+	}
+
+	// 구독하지 않은 마켓의 메시지는 전송되지 않으며, 실시간만 구독한 타입은 스냅샷 없이 받습니다.
+	srv.Publish("ticker", "KRW-XRP", nil)
+	srv.Publish("orderbook", "KRW-ETH", map[string]interface{}{"total_ask_size": 42})
+	orderbook := receive(t, func() (*public.Orderbook, error) { return client.GetOrderBook(time.UTC) })
+	if orderbook.Code != "KRW-ETH" || orderbook.TotalAskSize.String() != "42" || len(orderbook.OrderbookUnits) != 15 {
+		t.Errorf("orderbook = %s total_ask_size=%s units=%d, want the published KRW-ETH orderbook", orderbook.Code, orderbook.TotalAskSize, len(orderbook.OrderbookUnits))
+	}
+}
+
+func TestWebsocketPrivate(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	client, err := private.NewClient(srv.PrivateWebsocketURL(), auth.NewWebSocketTokenGen(srv.Credentials()), 0)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	defer client.Close()
+
+	err = client.Subscribe(nil,
+		private.AddSubscribe(private.MessageTypeMyOrder, nil, nil),
+		private.AddSubscribe(private.MessageTypeMyAsset, nil, nil),
+	)
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+
+	sub := waitSubscriptions(t, srv, 1)[0]
+	if !sub.Private || sub.Ticket == "" || len(sub.Types) != 2 || sub.Types[0].Type != "myOrder" || sub.Types[1].Type != "myAsset" {
+		t.Fatalf("subscription = %+v, want private myOrder and myAsset", sub)
+	}
+
+	client.StartMessageHandler()
+
+	// 직접 작성한 메시지를 보냅니다.
+	srv.Publish("myOrder", "KRW-BTC", map[string]interface{}{"uuid": "scripted-uuid", "state": "trade", "executed_volume": 0.005})
+	order := receive(t, func() (*private.MyOrder, error) { return client.GetMyOrder(time.UTC) })
+	if order.UUID != "scripted-uuid" || order.Code != "KRW-BTC" || order.State != common.OrderStateTrade || order.ExecutedVolume.String() != "0.005" {
+		t.Errorf("myOrder = %+v", order)
+	}
+
+	// REST API로 생성한 주문도 myOrder, myAsset으로 전달됩니다.
+	created, err := srv.Client().GetExchange().CreateOrder(&exchange.CreateOrderRequest{
+		Market:    "KRW-BTC",
+		Side:      exchange.OrderSideBid,
+		OrderType: exchange.OrderTypeLimit,
+		Price:     "40000000",
+		Volume:    "0.01",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder error: %v", err)
+	}
+	order = receive(t, func() (*private.MyOrder, error) { return client.GetMyOrder(time.UTC) })
+	if order.UUID != created.UUID || order.State != common.OrderStateWait || order.AskBid != common.AskBidTypeBid {
+		t.Errorf("myOrder = %+v, want the created order", order)
+	}
+	asset := receive(t, func() (*private.MyAsset, error) { return client.GetMyAsset(time.UTC) })
+	if len(asset.Assets) == 0 {
+		t.Error("myAsset has no assets")
+	}
+}
+
+func TestWebsocketPrivateRejectsBadToken(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	token := func(creds auth.Credentials) string {
+		t.Helper()
+		token, err := auth.NewWebSocketTokenGen(creds).GenerateToken()
+		if err != nil {
+			t.Fatalf("GenerateToken error: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "헤더 없음", authorization: ""},
+		{name: "Bearer 없음", authorization: "token"},
+		{name: "잘못된 토큰", authorization: "Bearer not-a-jwt"},
+		{name: "잘못된 비밀 키", authorization: token(auth.Credentials{AccessKey: upbittest.DefaultAccessKey, SecretKey: "wrong"})},
+		{name: "잘못된 접근 키", authorization: token(auth.Credentials{AccessKey: "wrong", SecretKey: upbittest.DefaultSecretKey})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.authorization != "" {
+				header.Set("Authorization", tt.authorization)
+			}
+			conn, resp, err := websocket.Dial(context.Background(), srv.PrivateWebsocketURL(), &websocket.DialOptions{HTTPHeader: header})
+			if err == nil {
+				conn.CloseNow()
+				t.Fatal("Dial succeeded")
+			}
+			if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("response = %v, want 401", resp)
+			}
+		})
+	}
+
+	// 비공개 클라이언트는 연결 실패를 에러로 반환합니다.
+	tokenGen := auth.NewWebSocketTokenGen(auth.Credentials{AccessKey: upbittest.DefaultAccessKey, SecretKey: "wrong"})
+	if _, err := private.NewClient(srv.PrivateWebsocketURL(), tokenGen, 0); err == nil {
+		t.Error("private.NewClient with a wrong secret key succeeded")
+	}
+	if n := srv.Connections(); n != 0 {
+		t.Errorf("Connections = %d, want 0", n)
+	}
+}
+
+func TestWebsocketReconnect(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+
+	client, err := public.NewClient(srv.PublicWebsocketURL(), nil, 0)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	defer client.Close()
+	defer client.Stop()
+
+	if err := client.Subscribe(nil, public.AddSubscribe(public.MessageTypeTicker, []string{"KRW-BTC", "KRW-ETH"}, nil)); err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	first := waitSubscriptions(t, srv, 1)[0]
+
+	client.StartMessageHandler()
+	for i := 0; i < 2; i++ {
+		receive(t, func() (*public.Ticker, error) { return client.GetTicker(time.UTC) })
+	}
+
+	// 연결이 끊기면 다시 연결하고 같은 구독을 복구합니다.
+	srv.DropConnections()
+	second := waitSubscriptions(t, srv, 2)[1]
+	if !slices.EqualFunc(first.Types, second.Types, func(a, b upbittest.SubscriptionType) bool {
+		return a.Type == b.Type && slices.Equal(a.Codes, b.Codes)
+	}) {
+		t.Errorf("resubscribed types = %+v, want %+v", second.Types, first.Types)
+	}
+	if n := srv.Connections(); n != 1 {
+		t.Errorf("Connections = %d, want 1", n)
+	}
+
+	// 복구한 연결로 메시지를 계속 받습니다.
+	ticker := receive(t, func() (*public.Ticker, error) { return client.GetTicker(time.UTC) })
+	if ticker.StreamType != common.StreamTypeSnapshot {
+		t.Errorf("stream type = %s, want snapshot after resubscribing", ticker.StreamType)
+	}
+	srv.Publish("ticker", "KRW-ETH", map[string]interface{}{"trade_price": 3100000})
+	for {
+		ticker = receive(t, func() (*public.Ticker, error) { return client.GetTicker(time.UTC) })
+		if ticker.StreamType == common.StreamTypeRealtime {
+			break
+		}
+	}
+	if ticker.Code != "KRW-ETH" || ticker.TradePrice.String() != "3100000" {
+		t.Errorf("realtime = %s %s, want KRW-ETH 3100000", ticker.Code, ticker.TradePrice)
+	}
+}
@@ -3,6 +3,7 @@ package websocket
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -23,21 +24,48 @@ type BaseClient struct {
 	TokenGen          auth.WebSocketTokenGenerator // 토큰 생성기
 	PingTicker        *time.Ticker                 // 핑 전송 타이머
 	PingInterval      time.Duration                // 핑 전송 간격
+	HTTPClient        *http.Client                 // 연결에 사용할 HTTP 클라이언트 (nil이면 기본값)
+	RequireAuth       bool                         // 인증 토큰 필수 여부
+	LazyConnect       bool                         // 첫 구독 시점까지 연결 지연 여부
 	reconnectAttempts int                          // 재연결 시도 횟수
 	maxReconnectTries int                          // 최대 재연결 시도 횟수
 	reconnectWait     time.Duration                // 재연결 대기 시간
 }
 
+// BaseClientOption은 웹소켓 기본 클라이언트의 설정을 변경하는 함수 타입입니다.
+type BaseClientOption func(*BaseClient)
+
+// WithHTTPClient는 웹소켓 연결에 사용할 HTTP 클라이언트를 설정하는 옵션을 반환합니다.
+// 프록시, TLS 설정, 사용자 정의 다이얼러는 httpClient의 Transport로 지정합니다.
+func WithHTTPClient(httpClient *http.Client) BaseClientOption {
+	return func(c *BaseClient) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithLazyConnect는 클라이언트 생성 시 연결하지 않고 첫 구독이나 메시지 읽기 시점에
+// 연결하도록 설정하는 옵션을 반환합니다.
+func WithLazyConnect() BaseClientOption {
+	return func(c *BaseClient) {
+		c.LazyConnect = true
+	}
+}
+
 // NewBaseClient는 새로운 웹소켓 기본 클라이언트를 생성합니다.
 // endpoint는 웹소켓 서버 주소, tokenGen은 토큰 생성기, pingInterval은 핑 전송 간격입니다.
-func NewBaseClient(endpoint string, tokenGen auth.WebSocketTokenGenerator, pingInterval time.Duration) *BaseClient {
-	return &BaseClient{
+// tokenGen이 nil이면 Authorization 헤더 없이 연결합니다.
+func NewBaseClient(endpoint string, tokenGen auth.WebSocketTokenGenerator, pingInterval time.Duration, opts ...BaseClientOption) *BaseClient {
+	c := &BaseClient{
 		Endpoint:          endpoint,
 		TokenGen:          tokenGen,
 		PingInterval:      pingInterval,
 		maxReconnectTries: 5,               // 기본값 설정
 		reconnectWait:     time.Second * 3, // 기본값 설정
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Connect는 웹소켓 서버에 연결합니다.
@@ -50,32 +78,100 @@ func (c *BaseClient) Connect() error {
 		return nil
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-
-	token, err := c.TokenGen.GenerateToken()
+	conn, ctx, cancel, err := c.dial()
 	if err != nil {
-		cancel()
-		return fmt.Errorf("토큰 생성 실패: %w", err)
+		return err
 	}
 
+	c.Conn = conn
+	c.Ctx = ctx
+	c.Cancel = cancel
+	c.IsRunning = true
+
+	c.startPingLoop()
+	return nil
+}
+
+// dial은 토큰 생성기로 새 토큰을 발급받아 웹소켓 서버에 연결합니다.
+func (c *BaseClient) dial() (*websocket.Conn, context.Context, context.CancelFunc, error) {
+	// 토큰 생성기가 없으면 인증 없이 연결 (공개 API 전용)
+	header := http.Header{}
+	if c.TokenGen == nil {
+		if c.RequireAuth {
+			return nil, nil, nil, auth.ErrNotAuthenticated
+		}
+	} else {
+		token, err := c.TokenGen.GenerateToken()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("토큰 생성 실패: %w", err)
+		}
+		header.Set("Authorization", token)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	conn, _, err := websocket.Dial(ctx, c.Endpoint, &websocket.DialOptions{
-		HTTPHeader: map[string][]string{
-			"Authorization": {token},
-		},
+		HTTPHeader:      header,
 		CompressionMode: websocket.CompressionContextTakeover,
+		HTTPClient:      c.HTTPClient,
 	})
 	if err != nil {
 		cancel()
-		return fmt.Errorf("웹소켓 연결 실패: %w", err)
+		return nil, nil, nil, fmt.Errorf("웹소켓 연결 실패: %w", err)
+	}
+	return conn, ctx, cancel, nil
+}
+
+// Refresh는 새 토큰으로 연결을 다시 맺고 기존 구독을 복구합니다.
+// API 키가 교체된 뒤 비공개 웹소켓에 새 키를 적용할 때 사용합니다.
+// 새 연결이 성공한 뒤에 기존 연결을 닫으므로 메시지 수신이 중단되지 않으며,
+// 연결되어 있지 않으면 아무것도 하지 않습니다 (다음 연결 시 새 토큰이 사용됩니다).
+func (c *BaseClient) Refresh() error {
+	c.Mu.Lock()
+	running := c.IsRunning
+	c.Mu.Unlock()
+	if !running {
+		return nil
+	}
+
+	conn, ctx, cancel, err := c.dial()
+	if err != nil {
+		return fmt.Errorf("재연결 실패: %w", err)
 	}
 
+	c.Mu.Lock()
+	if !c.IsRunning {
+		// 새 연결을 맺는 동안 Close가 호출된 경우
+		c.Mu.Unlock()
+		cancel()
+		conn.Close(websocket.StatusNormalClosure, "정상 종료")
+		return nil
+	}
+	oldConn, oldCancel := c.Conn, c.Cancel
 	c.Conn = conn
 	c.Ctx = ctx
 	c.Cancel = cancel
-	c.IsRunning = true
-
+	if c.PingTicker != nil {
+		c.PingTicker.Stop()
+	}
 	c.startPingLoop()
-	return nil
+	c.Mu.Unlock()
+
+	// 이전 구독 정보 복구
+	var subscribeErr error
+	if c.hasSubscriptions() {
+		if err := c.request(nil); err != nil {
+			subscribeErr = fmt.Errorf("구독 복구 실패: %w", err)
+		}
+	}
+
+	if oldCancel != nil {
+		oldCancel()
+	}
+	if oldConn != nil {
+		oldConn.Close(websocket.StatusNormalClosure, "키 교체")
+	}
+	return subscribeErr
 }
 
 // Ping은 웹소켓 서버에 핑을 전송합니다.
@@ -161,7 +257,7 @@ func (c *BaseClient) Reconnect() error {
 	}
 
 	// 이전 구독 정보 복구
-	if len(c.Messages) > 0 {
+	if c.hasSubscriptions() {
 		if err := c.request(nil); err != nil {
 			return fmt.Errorf("구독 복구 실패: %w", err)
 		}
@@ -180,14 +276,17 @@ func (c *BaseClient) startPingLoop() {
 		return
 	}
 
-	c.PingTicker = time.NewTicker(c.PingInterval)
+	// 잠금을 획득한 상태에서 호출되므로, Refresh나 Close가 필드를 바꿔도 안전하도록 현재 값을 복사합니다.
+	ticker := time.NewTicker(c.PingInterval)
+	ctx := c.Ctx
+	c.PingTicker = ticker
 
 	go func() {
 		for {
 			select {
-			case <-c.Ctx.Done():
+			case <-ctx.Done():
 				return
-			case <-c.PingTicker.C:
+			case <-ticker.C:
 				if err := c.Ping(); err != nil {
 					if strings.Contains(err.Error(), "컨텍스트 취소됨") {
 						return
@@ -27,6 +27,7 @@ type StatusResponse struct {
 }
 
 // SubscribeFunc는 구독 함수 타입을 정의합니다.
+// 구독 함수는 Mu를 잠근 상태에서 호출되므로 Mu를 잠그는 메서드를 호출하면 안 됩니다.
 type SubscribeFunc func(*BaseClient) error
 
 // AddSubscribe는 구독 함수를 생성합니다.
@@ -68,12 +69,21 @@ func AddSubscribe(messageType string, codes []string, options *common.SubscribeO
 
 // Subscribe는 지정된 구독 함수들을 사용하여 구독을 시작합니다.
 // ticket은 구독 식별자, f는 구독 함수 목록입니다.
+// 아직 연결되지 않은 경우 먼저 웹소켓 서버에 연결합니다.
 func (c *BaseClient) Subscribe(ticket *string, f ...SubscribeFunc) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+
+	// Refresh, Reconnect의 구독 복구와 동시에 실행될 수 있으므로 잠금을 획득한 상태에서 구독을 추가합니다.
+	c.Mu.Lock()
 	for _, fn := range f {
 		if err := fn(c); err != nil {
+			c.Mu.Unlock()
 			return err
 		}
 	}
+	c.Mu.Unlock()
 	return c.request(ticket)
 }
 
@@ -89,13 +99,20 @@ func (c *BaseClient) request(ticket *string) error {
 		{Ticket: *ticket},
 	}
 
-	if len(c.Messages) > 0 {
-		messages = append(messages, c.Messages...)
-	}
+	c.Mu.Lock()
+	messages = append(messages, c.Messages...)
+	c.Mu.Unlock()
 
 	return c.WriteJSON(messages)
 }
 
+// hasSubscriptions는 복구할 구독 정보가 있는지 확인합니다.
+func (c *BaseClient) hasSubscriptions() bool {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	return len(c.Messages) > 0
+}
+
 // WriteJSON은 JSON 데이터를 웹소켓으로 전송합니다.
 // 전송에 실패하면 에러를 반환합니다.
 func (c *BaseClient) WriteJSON(v interface{}) error {
@@ -130,16 +147,25 @@ func (c *BaseClient) ReadMessage() ([]byte, error) {
 		if err := c.Connect(); err != nil {
 			return nil, fmt.Errorf("연결 실패: %v", err)
 		}
-	} else {
-		c.Mu.Unlock()
+		c.Mu.Lock()
 	}
+	conn, ctx := c.Conn, c.Ctx
+	c.Mu.Unlock()
 
 	select {
-	case <-c.Ctx.Done():
+	case <-ctx.Done():
 		return nil, fmt.Errorf("컨텍스트 취소됨")
 	default:
-		_, data, err := c.Conn.Read(c.Ctx)
+		_, data, err := conn.Read(ctx)
 		if err != nil {
+			// Refresh로 연결이 교체된 경우 새 연결에서 다시 읽기
+			c.Mu.Lock()
+			replaced := c.IsRunning && c.Conn != nil && c.Conn != conn
+			c.Mu.Unlock()
+			if replaced {
+				return c.ReadMessage()
+			}
+
 			if websocket.CloseStatus(err) != -1 ||
 				strings.Contains(err.Error(), "failed to get reader") ||
 				strings.Contains(err.Error(), "use of closed network connection") {
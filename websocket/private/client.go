@@ -36,8 +36,13 @@ type Message struct {
 
 // NewClient는 새로운 개인 웹소켓 클라이언트를 생성합니다.
 // endpoint는 웹소켓 서버 주소, tokenGen은 토큰 생성기, pingInterval은 핑 전송 간격입니다.
-func NewClient(endpoint string, tokenGen *auth.WebSocketTokenGen, pingInterval time.Duration) (*Client, error) {
-	base := websocket.NewBaseClient(endpoint, tokenGen, pingInterval)
+// opts로 프록시나 TLS 설정 등 연결 방식을 지정할 수 있으며,
+// websocket.WithLazyConnect를 지정하면 첫 구독 시점에 연결합니다.
+// tokenGen이 nil이면 연결하지 않은 클라이언트를 반환하며, 이후 구독이나 연결 시
+// auth.ErrNotAuthenticated를 반환합니다.
+func NewClient(endpoint string, tokenGen auth.WebSocketTokenGenerator, pingInterval time.Duration, opts ...websocket.BaseClientOption) (*Client, error) {
+	base := websocket.NewBaseClient(endpoint, tokenGen, pingInterval, opts...)
+	base.RequireAuth = true
 
 	client := &Client{
 		BaseClient:  base,
@@ -47,6 +52,14 @@ func NewClient(endpoint string, tokenGen *auth.WebSocketTokenGen, pingInterval t
 		done:        make(chan struct{}),
 	}
 
+	if tokenGen == nil {
+		return client, nil
+	}
+
+	if base.LazyConnect {
+		return client, nil
+	}
+
 	if err := client.Connect(); err != nil {
 		return nil, err
 	}
@@ -54,6 +67,20 @@ func NewClient(endpoint string, tokenGen *auth.WebSocketTokenGen, pingInterval t
 	return client, nil
 }
 
+// Refresh는 새 토큰으로 연결을 다시 맺고 기존 구독을 복구합니다.
+// 실패하면 에러를 반환하고, GetMyOrder와 GetMyAsset에서도 받을 수 있도록 에러 채널에 전달합니다.
+func (c *Client) Refresh() error {
+	err := c.BaseClient.Refresh()
+	if err != nil {
+		select {
+		case c.errChan <- err:
+		default:
+			// 에러 채널이 가득 찬 경우 반환값으로만 알립니다.
+		}
+	}
+	return err
+}
+
 // AddSubscribe는 구독 함수를 생성합니다.
 // messageType은 메시지 유형, codes는 마켓 코드 목록, options는 구독 옵션입니다.
 func AddSubscribe(messageType PrivateMessageType, codes []string, options *common.SubscribeOptions) websocket.SubscribeFunc {
@@ -68,12 +95,20 @@ func AddSubscribe(messageType PrivateMessageType, codes []string, options *commo
 // Subscribe는 지정된 구독 함수들을 사용하여 구독을 시작합니다.
 // ticket은 구독 식별자, f는 구독 함수 목록입니다.
 func (c *Client) Subscribe(ticket *string, f ...websocket.SubscribeFunc) error {
+	if c.TokenGen == nil {
+		return auth.ErrNotAuthenticated
+	}
 	return c.BaseClient.Subscribe(ticket, f...)
 }
 
 // StartMessageHandler는 메시지 처리기를 시작합니다.
 // 수신된 메시지를 적절한 채널로 전달합니다.
 func (c *Client) StartMessageHandler() {
+	if c.TokenGen == nil {
+		c.errChan <- auth.ErrNotAuthenticated
+		return
+	}
+
 	go func() {
 		for {
 			select {
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hysuki/go-upbit/decimal"
 	"github.com/hysuki/go-upbit/websocket/common"
 )
 
 // Asset은 개별 자산 정보를 나타냅니다.
 type Asset struct {
-	Currency string  `json:"currency,omitempty"` // 화폐를 의미하는 영문 대문자 코드
-	Balance  float64 `json:"balance,omitempty"`  // 주문가능 수량
-	Locked   float64 `json:"locked,omitempty"`   // 주문 중 묶여있는 수량
+	Currency string          `json:"currency,omitempty"` // 화폐를 의미하는 영문 대문자 코드
+	Balance  decimal.Decimal `json:"balance"`            // 주문가능 수량
+	Locked   decimal.Decimal `json:"locked"`             // 주문 중 묶여있는 수량
 }
 
 // UpbitMyAsset은 내 자산 정보를 나타냅니다.
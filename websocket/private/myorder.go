@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hysuki/go-upbit/decimal"
 	"github.com/hysuki/go-upbit/websocket/common"
 )
 
@@ -17,17 +18,17 @@ type UpbitMyOrder struct {
 	OrderType       common.OrderType   `json:"order_type"`       // 주문 타입
 	State           common.OrderState  `json:"state"`            // 주문 상태
 	TradeUUID       string             `json:"trade_uuid"`       // 체결의 고유 ID
-	Price           float64            `json:"price"`            // 주문 가격
-	AvgPrice        float64            `json:"avg_price"`        // 평균 체결 가격
-	Volume          float64            `json:"volume"`           // 주문량
-	RemainingVolume float64            `json:"remaining_volume"` // 체결 후 남은 주문량
-	ExecutedVolume  float64            `json:"executed_volume"`  // 체결된 양
+	Price           decimal.Decimal    `json:"price"`            // 주문 가격
+	AvgPrice        decimal.Decimal    `json:"avg_price"`        // 평균 체결 가격
+	Volume          decimal.Decimal    `json:"volume"`           // 주문량
+	RemainingVolume decimal.Decimal    `json:"remaining_volume"` // 체결 후 남은 주문량
+	ExecutedVolume  decimal.Decimal    `json:"executed_volume"`  // 체결된 양
 	TradesCount     int                `json:"trades_count"`     // 해당 주문에 걸린 체결 수
-	ReservedFee     float64            `json:"reserved_fee"`     // 수수료로 예약된 비용
-	RemainingFee    float64            `json:"remaining_fee"`    // 남은 수수료
-	PaidFee         float64            `json:"paid_fee"`         // 사용된 수수료
-	Locked          float64            `json:"locked"`           // 거래에 사용중인 비용
-	ExecutedFunds   float64            `json:"executed_funds"`   // 체결된 금액
+	ReservedFee     decimal.Decimal    `json:"reserved_fee"`     // 수수료로 예약된 비용
+	RemainingFee    decimal.Decimal    `json:"remaining_fee"`    // 남은 수수료
+	PaidFee         decimal.Decimal    `json:"paid_fee"`         // 사용된 수수료
+	Locked          decimal.Decimal    `json:"locked"`           // 거래에 사용중인 비용
+	ExecutedFunds   decimal.Decimal    `json:"executed_funds"`   // 체결된 금액
 	TimeInForce     common.TimeInForce `json:"time_in_force"`    // IOC, FOK 설정
 	TradeTimestamp  int64              `json:"trade_timestamp"`  // 체결 타임스탬프
 	OrderTimestamp  int64              `json:"order_timestamp"`  // 주문 타임스탬프
@@ -44,17 +45,17 @@ type MyOrder struct {
 	OrderType       common.OrderType   `json:"order_type"`       // 주문 타입
 	State           common.OrderState  `json:"state"`            // 주문 상태
 	TradeUUID       string             `json:"trade_uuid"`       // 체결의 고유 ID
-	Price           float64            `json:"price"`            // 주문 가격
-	AvgPrice        float64            `json:"avg_price"`        // 평균 체결 가격
-	Volume          float64            `json:"volume"`           // 주문량
-	RemainingVolume float64            `json:"remaining_volume"` // 체결 후 남은 주문량
-	ExecutedVolume  float64            `json:"executed_volume"`  // 체결된 양
+	Price           decimal.Decimal    `json:"price"`            // 주문 가격
+	AvgPrice        decimal.Decimal    `json:"avg_price"`        // 평균 체결 가격
+	Volume          decimal.Decimal    `json:"volume"`           // 주문량
+	RemainingVolume decimal.Decimal    `json:"remaining_volume"` // 체결 후 남은 주문량
+	ExecutedVolume  decimal.Decimal    `json:"executed_volume"`  // 체결된 양
 	TradesCount     int                `json:"trades_count"`     // 해당 주문에 걸린 체결 수
-	ReservedFee     float64            `json:"reserved_fee"`     // 수수료로 예약된 비용
-	RemainingFee    float64            `json:"remaining_fee"`    // 남은 수수료
-	PaidFee         float64            `json:"paid_fee"`         // 사용된 수수료
-	Locked          float64            `json:"locked"`           // 거래에 사용중인 비용
-	ExecutedFunds   float64            `json:"executed_funds"`   // 체결된 금액
+	ReservedFee     decimal.Decimal    `json:"reserved_fee"`     // 수수료로 예약된 비용
+	RemainingFee    decimal.Decimal    `json:"remaining_fee"`    // 남은 수수료
+	PaidFee         decimal.Decimal    `json:"paid_fee"`         // 사용된 수수료
+	Locked          decimal.Decimal    `json:"locked"`           // 거래에 사용중인 비용
+	ExecutedFunds   decimal.Decimal    `json:"executed_funds"`   // 체결된 금액
 	TimeInForce     common.TimeInForce `json:"time_in_force"`    // IOC, FOK 설정
 	TradeTimestamp  time.Time          `json:"trade_timestamp"`  // 체결 시각 (trade_timestamp를 KST로 변환)
 	OrderTimestamp  time.Time          `json:"order_timestamp"`  // 주문 시각 (order_timestamp를 KST로 변환)
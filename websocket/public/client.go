@@ -39,8 +39,11 @@ type Message struct {
 
 // NewClient는 새로운 공개 웹소켓 클라이언트를 생성합니다.
 // endpoint는 웹소켓 서버 주소, tokenGen은 토큰 생성기, pingInterval은 핑 전송 간격입니다.
-func NewClient(endpoint string, tokenGen *auth.WebSocketTokenGen, pingInterval time.Duration) (*Client, error) {
-	base := websocket.NewBaseClient(endpoint, tokenGen, pingInterval)
+// opts로 프록시나 TLS 설정 등 연결 방식을 지정할 수 있으며,
+// websocket.WithLazyConnect를 지정하면 첫 구독 시점에 연결합니다.
+// 공개 API는 인증이 필요 없으므로 tokenGen은 nil이어도 됩니다.
+func NewClient(endpoint string, tokenGen auth.WebSocketTokenGenerator, pingInterval time.Duration, opts ...websocket.BaseClientOption) (*Client, error) {
+	base := websocket.NewBaseClient(endpoint, tokenGen, pingInterval, opts...)
 
 	client := &Client{
 		BaseClient:    base,
@@ -51,6 +54,10 @@ func NewClient(endpoint string, tokenGen *auth.WebSocketTokenGen, pingInterval t
 		done:          make(chan struct{}),
 	}
 
+	if base.LazyConnect {
+		return client, nil
+	}
+
 	if err := client.Connect(); err != nil {
 		return nil, err
 	}
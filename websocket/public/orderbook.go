@@ -4,36 +4,38 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/hysuki/go-upbit/decimal"
 )
 
 // UpbitOrderbook는 호가 정보를 나타냅니다.
 type UpbitOrderbook struct {
 	Type           string          `json:"type"`            // 타입 (orderbook)
 	Code           string          `json:"code"`            // 마켓 코드
-	TotalAskSize   float64         `json:"total_ask_size"`  // 호가 매도 총 잔량
-	TotalBidSize   float64         `json:"total_bid_size"`  // 호가 매수 총 잔량
+	TotalAskSize   decimal.Decimal `json:"total_ask_size"`  // 호가 매도 총 잔량
+	TotalBidSize   decimal.Decimal `json:"total_bid_size"`  // 호가 매수 총 잔량
 	OrderbookUnits []OrderbookUnit `json:"orderbook_units"` // 호가 정보 목록
 	Timestamp      int64           `json:"timestamp"`       // 타임스탬프
-	Level          float64         `json:"level"`           // 호가 모아보기 단위
+	Level          decimal.Decimal `json:"level"`           // 호가 모아보기 단위
 }
 
 // Orderbook은 내부적으로 사용하기 위한 호가 정보 구조체입니다.
 type Orderbook struct {
 	Type           string          `json:"type"`            // 타입 (orderbook)
 	Code           string          `json:"code"`            // 마켓 코드
-	TotalAskSize   float64         `json:"total_ask_size"`  // 호가 매도 총 잔량
-	TotalBidSize   float64         `json:"total_bid_size"`  // 호가 매수 총 잔량
+	TotalAskSize   decimal.Decimal `json:"total_ask_size"`  // 호가 매도 총 잔량
+	TotalBidSize   decimal.Decimal `json:"total_bid_size"`  // 호가 매수 총 잔량
 	OrderbookUnits []OrderbookUnit `json:"orderbook_units"` // 호가 정보 목록
 	Timestamp      time.Time       `json:"timestamp"`       // 타임스탬프 (KST)
-	Level          float64         `json:"level"`           // 호가 모아보기 단위
+	Level          decimal.Decimal `json:"level"`           // 호가 모아보기 단위
 }
 
 // OrderbookUnit은 내부적으로 사용하기 위한 개별 호가 정보 구조체입니다.
 type OrderbookUnit struct {
-	AskPrice float64 `json:"ask_price"` // 매도 호가
-	BidPrice float64 `json:"bid_price"` // 매수 호가
-	AskSize  float64 `json:"ask_size"`  // 매도 잔량
-	BidSize  float64 `json:"bid_size"`  // 매수 잔량
+	AskPrice decimal.Decimal `json:"ask_price"` // 매도 호가
+	BidPrice decimal.Decimal `json:"bid_price"` // 매수 호가
+	AskSize  decimal.Decimal `json:"ask_size"`  // 매도 잔량
+	BidSize  decimal.Decimal `json:"bid_size"`  // 매수 잔량
 }
 
 // NewOrderbook은 UpbitOrderbook을 내부 Orderbook 구조체로 변환합니다.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hysuki/go-upbit/decimal"
 	"github.com/hysuki/go-upbit/websocket/common"
 )
 
@@ -12,30 +13,30 @@ import (
 type UpbitTicker struct {
 	Type               string               `json:"type"`                  // 타입
 	Code               string               `json:"code"`                  // 마켓 코드
-	OpeningPrice       float64              `json:"opening_price"`         // 시가
-	HighPrice          float64              `json:"high_price"`            // 고가
-	LowPrice           float64              `json:"low_price"`             // 저가
-	TradePrice         float64              `json:"trade_price"`           // 현재가
-	PrevClosingPrice   float64              `json:"prev_closing_price"`    // 전일 종가
+	OpeningPrice       decimal.Decimal      `json:"opening_price"`         // 시가
+	HighPrice          decimal.Decimal      `json:"high_price"`            // 고가
+	LowPrice           decimal.Decimal      `json:"low_price"`             // 저가
+	TradePrice         decimal.Decimal      `json:"trade_price"`           // 현재가
+	PrevClosingPrice   decimal.Decimal      `json:"prev_closing_price"`    // 전일 종가
 	Change             common.ChangeType    `json:"change"`                // 전일 대비
-	ChangePrice        float64              `json:"change_price"`          // 변화액의 절대값
-	SignedChangePrice  float64              `json:"signed_change_price"`   // 전일 대비 값
-	ChangeRate         float64              `json:"change_rate"`           // 부호 없는 전일 대비 등락율
-	SignedChangeRate   float64              `json:"signed_change_rate"`    // 전일 대비 등락율
-	TradeVolume        float64              `json:"trade_volume"`          // 가장 최근 거래량
-	AccTradeVolume     float64              `json:"acc_trade_volume"`      // 누적 거래량
-	AccTradeVolume24h  float64              `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
-	AccTradePrice      float64              `json:"acc_trade_price"`       // 누적 거래대금
-	AccTradePrice24h   float64              `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
+	ChangePrice        decimal.Decimal      `json:"change_price"`          // 변화액의 절대값
+	SignedChangePrice  decimal.Decimal      `json:"signed_change_price"`   // 전일 대비 값
+	ChangeRate         decimal.Decimal      `json:"change_rate"`           // 부호 없는 전일 대비 등락율
+	SignedChangeRate   decimal.Decimal      `json:"signed_change_rate"`    // 전일 대비 등락율
+	TradeVolume        decimal.Decimal      `json:"trade_volume"`          // 가장 최근 거래량
+	AccTradeVolume     decimal.Decimal      `json:"acc_trade_volume"`      // 누적 거래량
+	AccTradeVolume24h  decimal.Decimal      `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
+	AccTradePrice      decimal.Decimal      `json:"acc_trade_price"`       // 누적 거래대금
+	AccTradePrice24h   decimal.Decimal      `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
 	TradeDate          string               `json:"trade_date"`            // 최근 거래 일자(UTC)
 	TradeTime          string               `json:"trade_time"`            // 최근 거래 시각(UTC)
 	TradeTimestamp     int64                `json:"trade_timestamp"`       // 체결 타임스탬프
 	AskBid             common.AskBidType    `json:"ask_bid"`               // 매수/매도 구분
-	AccAskVolume       float64              `json:"acc_ask_volume"`        // 누적 매도량
-	AccBidVolume       float64              `json:"acc_bid_volume"`        // 누적 매수량
-	Highest52WeekPrice float64              `json:"highest_52_week_price"` // 52주 신고가
+	AccAskVolume       decimal.Decimal      `json:"acc_ask_volume"`        // 누적 매도량
+	AccBidVolume       decimal.Decimal      `json:"acc_bid_volume"`        // 누적 매수량
+	Highest52WeekPrice decimal.Decimal      `json:"highest_52_week_price"` // 52주 신고가
 	Highest52WeekDate  string               `json:"highest_52_week_date"`  // 52주 신고가 달성일
-	Lowest52WeekPrice  float64              `json:"lowest_52_week_price"`  // 52주 신저가
+	Lowest52WeekPrice  decimal.Decimal      `json:"lowest_52_week_price"`  // 52주 신저가
 	Lowest52WeekDate   string               `json:"lowest_52_week_date"`   // 52주 신저가 달성일
 	MarketState        common.MarketState   `json:"market_state"`          // 거래상태
 	MarketWarning      common.MarketWarning `json:"market_warning"`        // 거래경고
@@ -47,30 +48,30 @@ type UpbitTicker struct {
 type Ticker struct {
 	Type               string               `json:"type"`                  // 타입
 	Code               string               `json:"code"`                  // 마켓 코드
-	OpeningPrice       float64              `json:"opening_price"`         // 시가
-	HighPrice          float64              `json:"high_price"`            // 고가
-	LowPrice           float64              `json:"low_price"`             // 저가
-	TradePrice         float64              `json:"trade_price"`           // 현재가
-	PrevClosingPrice   float64              `json:"prev_closing_price"`    // 전일 종가
+	OpeningPrice       decimal.Decimal      `json:"opening_price"`         // 시가
+	HighPrice          decimal.Decimal      `json:"high_price"`            // 고가
+	LowPrice           decimal.Decimal      `json:"low_price"`             // 저가
+	TradePrice         decimal.Decimal      `json:"trade_price"`           // 현재가
+	PrevClosingPrice   decimal.Decimal      `json:"prev_closing_price"`    // 전일 종가
 	Change             common.ChangeType    `json:"change"`                // 전일 대비
-	ChangePrice        float64              `json:"change_price"`          // 변화액의 절대값
-	SignedChangePrice  float64              `json:"signed_change_price"`   // 전일 대비 값
-	ChangeRate         float64              `json:"change_rate"`           // 부호 없는 전일 대비 등락율
-	SignedChangeRate   float64              `json:"signed_change_rate"`    // 전일 대비 등락율
-	TradeVolume        float64              `json:"trade_volume"`          // 가장 최근 거래량
-	AccTradeVolume     float64              `json:"acc_trade_volume"`      // 누적 거래량
-	AccTradeVolume24h  float64              `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
-	AccTradePrice      float64              `json:"acc_trade_price"`       // 누적 거래대금
-	AccTradePrice24h   float64              `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
+	ChangePrice        decimal.Decimal      `json:"change_price"`          // 변화액의 절대값
+	SignedChangePrice  decimal.Decimal      `json:"signed_change_price"`   // 전일 대비 값
+	ChangeRate         decimal.Decimal      `json:"change_rate"`           // 부호 없는 전일 대비 등락율
+	SignedChangeRate   decimal.Decimal      `json:"signed_change_rate"`    // 전일 대비 등락율
+	TradeVolume        decimal.Decimal      `json:"trade_volume"`          // 가장 최근 거래량
+	AccTradeVolume     decimal.Decimal      `json:"acc_trade_volume"`      // 누적 거래량
+	AccTradeVolume24h  decimal.Decimal      `json:"acc_trade_volume_24h"`  // 24시간 누적 거래량
+	AccTradePrice      decimal.Decimal      `json:"acc_trade_price"`       // 누적 거래대금
+	AccTradePrice24h   decimal.Decimal      `json:"acc_trade_price_24h"`   // 24시간 누적 거래대금
 	TradeDate          string               `json:"trade_date"`            // 최근 거래 일자(UTC)
 	TradeTime          string               `json:"trade_time"`            // 최근 거래 시각(UTC)
 	TradeTimestamp     time.Time            `json:"trade_timestamp"`       // 체결 시각 (trade_timestamp를 KST로 변환)
 	AskBid             common.AskBidType    `json:"ask_bid"`               // 매수/매도 구분
-	AccAskVolume       float64              `json:"acc_ask_volume"`        // 누적 매도량
-	AccBidVolume       float64              `json:"acc_bid_volume"`        // 누적 매수량
-	Highest52WeekPrice float64              `json:"highest_52_week_price"` // 52주 신고가
+	AccAskVolume       decimal.Decimal      `json:"acc_ask_volume"`        // 누적 매도량
+	AccBidVolume       decimal.Decimal      `json:"acc_bid_volume"`        // 누적 매수량
+	Highest52WeekPrice decimal.Decimal      `json:"highest_52_week_price"` // 52주 신고가
 	Highest52WeekDate  string               `json:"highest_52_week_date"`  // 52주 신고가 달성일
-	Lowest52WeekPrice  float64              `json:"lowest_52_week_price"`  // 52주 신저가
+	Lowest52WeekPrice  decimal.Decimal      `json:"lowest_52_week_price"`  // 52주 신저가
 	Lowest52WeekDate   string               `json:"lowest_52_week_date"`   // 52주 신저가 달성일
 	MarketState        common.MarketState   `json:"market_state"`          // 거래상태
 	MarketWarning      common.MarketWarning `json:"market_warning"`        // 거래경고
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hysuki/go-upbit/decimal"
 	"github.com/hysuki/go-upbit/websocket/common"
 )
 
@@ -12,12 +13,12 @@ import (
 type UpbitTrade struct {
 	Type             string            `json:"type"`               // 타입
 	Code             string            `json:"code"`               // 마켓 코드
-	TradePrice       float64           `json:"trade_price"`        // 체결 가격
-	TradeVolume      float64           `json:"trade_volume"`       // 체결량
+	TradePrice       decimal.Decimal   `json:"trade_price"`        // 체결 가격
+	TradeVolume      decimal.Decimal   `json:"trade_volume"`       // 체결량
 	AskBid           common.AskBidType `json:"ask_bid"`            // 매수/매도 구분
-	PrevClosingPrice float64           `json:"prev_closing_price"` // 전일 종가
+	PrevClosingPrice decimal.Decimal   `json:"prev_closing_price"` // 전일 종가
 	Change           string            `json:"change"`             // 전일 대비
-	ChangePrice      float64           `json:"change_price"`       // 부호 없는 전일 대비 값
+	ChangePrice      decimal.Decimal   `json:"change_price"`       // 부호 없는 전일 대비 값
 	TradeDate        string            `json:"trade_date"`         // 체결 일자(UTC)
 	TradeTime        string            `json:"trade_time"`         // 체결 시각(UTC)
 	TradeTimestamp   int64             `json:"trade_timestamp"`    // 체결 타임스탬프
@@ -30,12 +31,12 @@ type UpbitTrade struct {
 type Trade struct {
 	Type             string            `json:"type"`               // 타입 (trade)
 	Code             string            `json:"code"`               // 마켓 코드
-	TradePrice       float64           `json:"trade_price"`        // 체결 가격
-	TradeVolume      float64           `json:"trade_volume"`       // 체결량
+	TradePrice       decimal.Decimal   `json:"trade_price"`        // 체결 가격
+	TradeVolume      decimal.Decimal   `json:"trade_volume"`       // 체결량
 	AskBid           common.AskBidType `json:"ask_bid"`            // 매수/매도 구분
-	PrevClosingPrice float64           `json:"prev_closing_price"` // 전일 종가
+	PrevClosingPrice decimal.Decimal   `json:"prev_closing_price"` // 전일 종가
 	Change           string            `json:"change"`             // 전일 대비
-	ChangePrice      float64           `json:"change_price"`       // 부호 없는 전일 대비 값
+	ChangePrice      decimal.Decimal   `json:"change_price"`       // 부호 없는 전일 대비 값
 	TradeDate        string            `json:"trade_date"`         // 체결 일자(UTC)
 	TradeTime        string            `json:"trade_time"`         // 체결 시각(UTC)
 	TradeTimestamp   time.Time         `json:"trade_timestamp"`    // 체결 시각 (trade_timestamp를 KST로 변환)